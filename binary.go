@@ -0,0 +1,77 @@
+package hdur
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// binaryVersion is the current version of the binary encoding
+const binaryVersion byte = 1
+
+// AppendBinary appends the binary encoding of the duration to b.
+//
+// The encoding is a version byte (currently 1) followed by the Years, Months,
+// Days, Hours, Minutes, Seconds and Nanos components in that order, each
+// written as a zig-zag signed varint as produced by binary.AppendVarint.
+// Components are stored as is, without normalization, so decoding always
+// returns the exact value that was encoded.
+func (d Duration) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, binaryVersion)
+	for _, v := range d.components() {
+		b = binary.AppendVarint(b, int64(v))
+	}
+	return b, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, 8))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("invalid binary duration: no data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("unsupported binary duration version: %d", data[0])
+	}
+	data = data[1:]
+
+	var values [7]int
+	for i := range values {
+		v, n := binary.Varint(data)
+		if n <= 0 {
+			return fmt.Errorf("invalid binary duration: malformed component %d", i)
+		}
+		if int64(int(v)) != v {
+			return fmt.Errorf("invalid binary duration: component %d overflows int", i)
+		}
+		values[i] = int(v)
+		data = data[n:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("invalid binary duration: %d trailing bytes", len(data))
+	}
+
+	*d = Duration{
+		Years:   values[0],
+		Months:  values[1],
+		Days:    values[2],
+		Hours:   values[3],
+		Minutes: values[4],
+		Seconds: values[5],
+		Nanos:   values[6],
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding
+func (d Duration) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding
+func (d *Duration) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
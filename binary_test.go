@@ -0,0 +1,154 @@
+package hdur
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// TestDurationBinary_WireFormat pins the binary encoding. These bytes may be
+// persisted by callers, so existing vectors must never change; a new
+// encoding needs a new version byte.
+func TestDurationBinary_WireFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		hex  string
+	}{
+		{
+			name: "zero",
+			d:    Duration{},
+			hex:  "01" + "00" + "00" + "00" + "00" + "00" + "00" + "00",
+		},
+		{
+			name: "small positive components",
+			d:    Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanos: 7},
+			hex:  "01" + "02" + "04" + "06" + "08" + "0a" + "0c" + "0e",
+		},
+		{
+			name: "negative components",
+			d:    Duration{Days: -1, Hours: -12},
+			hex:  "01" + "00" + "00" + "01" + "17" + "00" + "00" + "00",
+		},
+		{
+			name: "multi-byte nanoseconds",
+			d:    Duration{Nanos: 500000000},
+			hex:  "01" + "00" + "00" + "00" + "00" + "00" + "00" + "8094ebdc03",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.hex {
+				t.Errorf("MarshalBinary() = %x, want %s", got, tt.hex)
+			}
+
+			data, _ := hex.DecodeString(tt.hex)
+			var d Duration
+			if err := d.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() error = %v", err)
+			}
+			if d != tt.d {
+				t.Errorf("UnmarshalBinary() = %#v, want %#v", d, tt.d)
+			}
+		})
+	}
+}
+
+func TestDurationBinary_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"unknown version", []byte{2, 0, 0, 0, 0, 0, 0, 0}},
+		{"truncated", []byte{1, 0, 0, 0}},
+		{"malformed varint", []byte{1, 0, 0, 0, 0, 0, 0, 0x80}},
+		{"trailing bytes", []byte{1, 0, 0, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			if err := d.UnmarshalBinary(tt.data); err == nil {
+				t.Error("UnmarshalBinary() expected error")
+			}
+		})
+	}
+}
+
+func TestDurationBinary_RoundTrip(t *testing.T) {
+	durations := []Duration{
+		MustParseDuration("1y 2mo 3d 4h 5m 6s"),
+		{Hours: 1, Minutes: -30},
+		{Days: 45, Hours: 30},
+		{Years: math.MaxInt64, Nanos: math.MinInt64},
+	}
+
+	for _, d := range durations {
+		data, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+
+		var got Duration
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary() error = %v", err)
+		}
+		if got != d {
+			t.Errorf("round trip = %#v, want %#v", got, d)
+		}
+	}
+}
+
+func TestDurationGob(t *testing.T) {
+	type record struct {
+		Name string
+		TTL  Duration
+	}
+	in := record{Name: "cache", TTL: Duration{Days: 1, Nanos: 1500}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if out != in {
+		t.Errorf("Decode() = %#v, want %#v", out, in)
+	}
+}
+
+func FuzzDurationBinary(f *testing.F) {
+	f.Add([]byte{1, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{1, 2, 4, 6, 8, 10, 12, 14})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d Duration
+		if err := d.UnmarshalBinary(data); err != nil {
+			return
+		}
+
+		encoded, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+
+		var again Duration
+		if err := again.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("UnmarshalBinary(%x) error = %v", encoded, err)
+		}
+		if again != d {
+			t.Errorf("round trip = %#v, want %#v", again, d)
+		}
+	})
+}
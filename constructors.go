@@ -48,28 +48,13 @@ func Hours(h float64) Duration {
 	return d
 }
 
-// Days creates a Duration from a number of days
+// Days creates a Duration from a number of days. The days are kept as days
+// rather than folded into months, since a month has no fixed number of days;
+// use BalanceDays to fold them explicitly.
 func Days(days float64) Duration {
 	d := Duration{}
 	total := int(days)
-	isNegative := days < 0
-	if isNegative {
-		total = -total
-	}
-
-	// Convert days to months when appropriate
-	if total >= 30 {
-		d.Months = total / 30
-		total = total % 30
-	}
-
 	d.Days = total
-
-	if isNegative {
-		d.Months = -d.Months
-		d.Days = -d.Days
-	}
-
 	d.normalize()
 	return d
 }
@@ -79,18 +64,17 @@ func Weeks(weeks float64) Duration {
 	return Days(weeks * 7)
 }
 
-// Months creates a Duration from a number of months
+// Months creates a Duration from a number of months. A fractional month
+// carries into days and smaller units using DefaultConvention, so Months(1.5)
+// is 1 month and half of an average month.
 func Months(months float64) Duration {
-	d := Duration{}
-	total := int(months)
-	d.Months = total
-	d.normalize()
-	return d
+	return DefaultConvention.Months(months)
 }
 
-// Years creates a Duration from a number of years
+// Years creates a Duration from a number of years. A fractional month
+// carries into days and smaller units using DefaultConvention.
 func Years(years float64) Duration {
-	return Months(years * 12)
+	return DefaultConvention.Years(years)
 }
 
 // FromStandard converts a time.Duration to our Duration type
@@ -101,38 +85,93 @@ func FromStandard(d time.Duration) Duration {
 	}
 }
 
-// InHours returns the duration as a floating-point number of hours
+// InHours returns the duration as a floating-point number of hours, using
+// DefaultConvention for years and months
 func (d Duration) InHours() float64 {
-	t := time.Now()
-	return d.Add(t).Sub(t).Hours()
+	return DefaultConvention.In(d, UnitHour)
 }
 
-// InMinutes returns the duration as a floating-point number of minutes
+// InMinutes returns the duration as a floating-point number of minutes,
+// using DefaultConvention for years and months
 func (d Duration) InMinutes() float64 {
-	t := time.Now()
-	return d.Add(t).Sub(t).Minutes()
+	return DefaultConvention.In(d, UnitMinute)
 }
 
-// InSeconds returns the duration as a floating-point number of seconds
+// InSeconds returns the duration as a floating-point number of seconds,
+// using DefaultConvention for years and months
 func (d Duration) InSeconds() float64 {
-	t := time.Now()
-	return d.Add(t).Sub(t).Seconds()
+	return DefaultConvention.In(d, UnitSecond)
 }
 
-// InNanoseconds returns the duration as an integer number of nanoseconds
+// InNanoseconds returns the duration as an integer number of nanoseconds,
+// using DefaultConvention for years and months. Durations beyond the range
+// of an int64 are clamped to math.MaxInt64 or math.MinInt64.
 func (d Duration) InNanoseconds() int64 {
-	t := time.Now()
-	return d.Add(t).Sub(t).Nanoseconds()
+	return int64(d.ToStandardSaturating())
 }
 
-// InMonths returns the approximate number of months in the duration
+// InMonths returns the approximate number of months in the duration, using
+// DefaultConvention for days and smaller units
 func (d Duration) InMonths() float64 {
-	return float64(d.Years*12+d.Months) + float64(d.Days)/30.44
+	return DefaultConvention.In(d, UnitMonth)
 }
 
-// InYears returns the approximate number of years in the duration
+// InYears returns the approximate number of years in the duration, using
+// DefaultConvention for days and smaller units
 func (d Duration) InYears() float64 {
-	return float64(d.Years) + float64(d.Months)/12.0 + float64(d.Days)/365.25
+	return DefaultConvention.In(d, UnitYear)
+}
+
+// InHoursAt returns the duration as a floating-point number of hours when
+// applied to ref
+func (d Duration) InHoursAt(ref time.Time) float64 {
+	return d.ToStandardAt(ref).Hours()
+}
+
+// InMinutesAt returns the duration as a floating-point number of minutes
+// when applied to ref
+func (d Duration) InMinutesAt(ref time.Time) float64 {
+	return d.ToStandardAt(ref).Minutes()
+}
+
+// InSecondsAt returns the duration as a floating-point number of seconds
+// when applied to ref
+func (d Duration) InSecondsAt(ref time.Time) float64 {
+	return d.ToStandardAt(ref).Seconds()
+}
+
+// InNanosecondsAt returns the duration as an integer number of nanoseconds
+// when applied to ref
+func (d Duration) InNanosecondsAt(ref time.Time) int64 {
+	return d.ToStandardAt(ref).Nanoseconds()
+}
+
+// InMonthsAt returns the number of calendar months the duration spans when
+// applied to ref, with any remainder as a fraction of the month it falls in:
+// 14 days 12 hours from February 1, 2024 is half a month.
+func (d Duration) InMonthsAt(ref time.Time) float64 {
+	return calendarUnitsAt(d.Add(ref), ref, 1)
+}
+
+// InYearsAt returns the number of calendar years the duration spans when
+// applied to ref, with any remainder as a fraction of the year it falls in
+func (d Duration) InYearsAt(ref time.Time) float64 {
+	return calendarUnitsAt(d.Add(ref), ref, 12)
+}
+
+// calendarUnitsAt returns the number of units of unitMonths months from ref
+// to target, counting the remainder as a fraction of the next unit
+func calendarUnitsAt(target, ref time.Time, unitMonths int) float64 {
+	diff := Sub(target, ref)
+	whole := (diff.Years*12 + diff.Months) / unitMonths
+
+	direction := 1
+	if target.Before(ref) {
+		direction = -1
+	}
+	start := Duration{Months: whole * unitMonths}.Add(ref)
+	end := Duration{Months: (whole + direction) * unitMonths}.Add(ref)
+	return float64(whole) + float64(direction)*calendarProgress(start, end, target)
 }
 
 // Common durations
@@ -1,6 +1,10 @@
 package hdur
 
-import "testing"
+import (
+	"math"
+	"testing"
+	"time"
+)
 
 func TestDurationConstructors(t *testing.T) {
 	tests := []struct {
@@ -66,7 +70,7 @@ func TestDurationConstructors(t *testing.T) {
 		{
 			name:     "days above month",
 			input:    Days(45),
-			expected: "1mo 15d",
+			expected: "45d",
 		},
 		{
 			name:     "weeks",
@@ -239,7 +243,13 @@ func TestDuration_Constructors_Comprehensive(t *testing.T) {
 			name:  "months fractional",
 			fn:    Months,
 			input: 1.5,
-			want:  Duration{Months: 1}, // Should truncate
+			want:  Duration{Months: 1, Days: 15, Hours: 5, Minutes: 14, Seconds: 33}, // Half a Gregorian month
+		},
+		{
+			name:  "years fractional",
+			fn:    Years,
+			input: 0.5,
+			want:  Duration{Months: 6},
 		},
 		{
 			name:  "months large value",
@@ -268,32 +278,32 @@ func TestDays_Edge_Cases(t *testing.T) {
 		{
 			name: "exactly 30 days",
 			days: 30,
-			want: Duration{Months: 1},
+			want: Duration{Days: 30},
 		},
 		{
 			name: "31 days",
 			days: 31,
-			want: Duration{Months: 1, Days: 1},
+			want: Duration{Days: 31},
 		},
 		{
 			name: "negative 30 days",
 			days: -30,
-			want: Duration{Months: -1},
+			want: Duration{Days: -30},
 		},
 		{
 			name: "negative 31 days",
 			days: -31,
-			want: Duration{Months: -1, Days: -1},
+			want: Duration{Days: -31},
 		},
 		{
 			name: "60 days",
 			days: 60,
-			want: Duration{Months: 2},
+			want: Duration{Days: 60},
 		},
 		{
 			name: "365 days",
 			days: 365,
-			want: Duration{Months: 12, Days: 5},
+			want: Duration{Days: 365},
 		},
 		{
 			name: "zero days",
@@ -303,7 +313,7 @@ func TestDays_Edge_Cases(t *testing.T) {
 		{
 			name: "fractional days",
 			days: 30.5,
-			want: Duration{Months: 1, Days: 0}, // Should truncate
+			want: Duration{Days: 30}, // Should truncate
 		},
 	}
 
@@ -316,3 +326,60 @@ func TestDays_Edge_Cases(t *testing.T) {
 		})
 	}
 }
+
+func TestDays_KeepsDayCount(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		d    Duration
+		want time.Time
+	}{
+		{"45 days", Days(45), time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)},
+		{"365 days", Days(365), time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"six weeks", Weeks(6), time.Date(2023, time.February, 12, 0, 0, 0, 0, time.UTC)},
+		{"parsed days", MustParseDuration("45 days"), time.Date(2023, time.February, 15, 0, 0, 0, 0, time.UTC)},
+		{"parsed ISO weeks", MustParseDuration("P6W"), time.Date(2023, time.February, 12, 0, 0, 0, 0, time.UTC)},
+		{"constant", Days90, time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.d.Months != 0 || tt.d.Years != 0 {
+				t.Errorf("%v has calendar units", tt.d)
+			}
+			if got := tt.d.Add(start); !got.Equal(tt.want) {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_InAt(t *testing.T) {
+	feb := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	jan := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"hours in leap february", Months(1).InHoursAt(feb), 29 * 24},
+		{"minutes", Days(1).InMinutesAt(feb), 24 * 60},
+		{"seconds", Duration{Months: 1, Seconds: 1}.InSecondsAt(feb), 29*24*60*60 + 1},
+		{"nanoseconds", float64(Duration{Seconds: 1, Nanos: 5}.InNanosecondsAt(feb)), 1000000005},
+		{"half of february", Duration{Days: 14, Hours: 12}.InMonthsAt(feb), 0.5},
+		{"month and a half", Duration{Months: 1, Days: 15, Hours: 12}.InMonthsAt(jan), 1 + 15.5/28},
+		{"days beyond a month", Duration{Days: 45}.InMonthsAt(jan), 1.5},
+		{"negative months", Duration{Months: -1, Days: -15}.InMonthsAt(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)), -1 - 15.0/31},
+		{"years", Duration{Years: 1, Months: 6}.InYearsAt(jan), 1 + 182.0/366},
+		{"year in days", Days(365).InYearsAt(jan), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-9 {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
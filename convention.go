@@ -0,0 +1,163 @@
+package hdur
+
+import (
+	"cmp"
+	"math"
+	"time"
+)
+
+// Convention fixes the length of months and years for conversions that are
+// not measured from a particular date. Days always count as 24 hours.
+//
+// Years and months convert to each other exactly, twelve months to a year,
+// so a Convention only comes into play when calendar units are compared
+// with or converted to days and smaller units.
+type Convention struct {
+	DaysPerMonth float64
+	DaysPerYear  float64
+}
+
+// Common conventions
+var (
+	// Convention360 uses 30-day months and 360-day years, as in the 30/360
+	// day count used by bond markets
+	Convention360 = Convention{DaysPerMonth: 30, DaysPerYear: 360}
+
+	// Convention365 uses 365-day years split into twelve equal months
+	Convention365 = Convention{DaysPerMonth: 365.0 / 12, DaysPerYear: 365}
+
+	// ConventionJulian uses the average month and year of the Julian
+	// calendar, 30.4375 and 365.25 days
+	ConventionJulian = Convention{DaysPerMonth: 30.4375, DaysPerYear: 365.25}
+
+	// ConventionGregorian uses the average month and year of the Gregorian
+	// calendar, 30.436875 and 365.2425 days
+	ConventionGregorian = Convention{DaysPerMonth: 30.436875, DaysPerYear: 365.2425}
+)
+
+// DefaultConvention is the convention used by ToStandard, Compare, the InX
+// methods, Mul and Div, and the Months and Years constructors. It may be
+// changed at program start to suit the application, but should not be
+// changed while durations are being converted.
+var DefaultConvention = ConventionGregorian
+
+// dayNanos is the length of a day in nanoseconds
+const dayNanos = float64(24 * time.Hour)
+
+// calendarNanos returns the length of the years and months of d in
+// nanoseconds. d must be normalized so that twelve months always count as a
+// year.
+func (c Convention) calendarNanos(d Duration) float64 {
+	return float64(d.Years)*(c.DaysPerYear*dayNanos) + float64(d.Months)*(c.DaysPerMonth*dayNanos)
+}
+
+// In returns the length of d in the given unit. Conversions between years
+// and months are exact; days and smaller units convert to months and years
+// using the convention, and months and years convert to smaller units the
+// same way.
+func (c Convention) In(d Duration, unit Unit) float64 {
+	d.normalize()
+	fixedDays := d.floatNanos() / dayNanos
+
+	switch unit {
+	case UnitYear:
+		return float64(d.Years) + float64(d.Months)/12 + fixedDays/c.DaysPerYear
+	case UnitMonth:
+		return float64(d.Years*12+d.Months) + fixedDays/c.DaysPerMonth
+	default:
+		nanos := c.calendarNanos(d) + d.floatNanos()
+		return nanos / float64(unit.nanos())
+	}
+}
+
+// ToStandard converts d to a time.Duration using the convention, rounding
+// to the nearest nanosecond
+func (c Convention) ToStandard(d Duration) time.Duration {
+	d.normalize()
+	calendar := math.Round(c.calendarNanos(d))
+	return time.Duration(calendar) + time.Duration(d.fixedNanos())
+}
+
+// toStandard converts d to a time.Duration using the convention, clamping
+// it to the range of time.Duration. It reports whether d was in range.
+func (c Convention) toStandard(d Duration) (time.Duration, bool) {
+	d.normalize()
+	calendar := math.Round(c.calendarNanos(d))
+
+	// Add exactly when both parts fit in an int64
+	if fixed, ok := d.checkedFixedNanos(); ok && math.Abs(calendar) < maxStandard {
+		if total, ok := addInt64(int64(calendar), fixed); ok {
+			return time.Duration(total), true
+		}
+	}
+
+	// Otherwise the parts may still cancel out, so decide using the
+	// approximate total
+	total := calendar + d.floatNanos()
+	switch {
+	case total >= maxStandard:
+		return math.MaxInt64, false
+	case total < -maxStandard:
+		return math.MinInt64, false
+	default:
+		return time.Duration(total), true
+	}
+}
+
+// Compare returns -1 if a is shorter than b under the convention, 0 if they
+// are the same length and +1 if a is longer
+func (c Convention) Compare(a, b Duration) int {
+	a.normalize()
+	b.normalize()
+
+	// Days and the time of day are compared separately so that long
+	// durations cannot overflow
+	days := int64(a.Days) - int64(b.Days)
+	clock := a.clockNanos() - b.clockNanos()
+
+	if a.Years == b.Years && a.Months == b.Months {
+		// Compare exactly, so that a nanosecond difference is not lost next
+		// to a year. The time of day differs by less than two days, so
+		// beyond that the days decide.
+		if days > 1 || days < -1 {
+			return cmp.Compare(days, 0)
+		}
+		return cmp.Compare(days*int64(24*time.Hour)+clock, 0)
+	}
+
+	diff := math.Round(c.calendarNanos(a)-c.calendarNanos(b)) + float64(days)*dayNanos + float64(clock)
+	return cmp.Compare(diff, 0)
+}
+
+// clockNanos returns the total nanoseconds in the hours through nanoseconds
+// of d. Once d is normalized they make up less than a day, so this cannot
+// overflow.
+func (d Duration) clockNanos() int64 {
+	return int64(d.Hours)*int64(time.Hour) + int64(d.Minutes)*int64(time.Minute) +
+		int64(d.Seconds)*int64(time.Second) + int64(d.Nanos)
+}
+
+// Months creates a Duration from a number of months, carrying any
+// fractional month into days and smaller units using the convention:
+// Convention360.Months(1.5) is 1 month 15 days.
+func (c Convention) Months(months float64) Duration {
+	whole := math.Trunc(months)
+	return withFractionalDays(Duration{Months: int(whole)}, (months-whole)*c.DaysPerMonth)
+}
+
+// Years creates a Duration from a number of years, carrying any fractional
+// month into days and smaller units using the convention
+func (c Convention) Years(years float64) Duration {
+	whole := math.Trunc(years * 12)
+	return withFractionalDays(Duration{Months: int(whole)}, (years*12-whole)*c.DaysPerYear/12)
+}
+
+// withFractionalDays adds days, which may be fractional, to d and normalizes
+// the result, rounding to the nearest nanosecond
+func withFractionalDays(d Duration, days float64) Duration {
+	whole := math.Trunc(days)
+	d.Days = int(whole)
+	d.Nanos = int(math.Round((days - whole) * dayNanos))
+	d.normalize()
+	return d
+}
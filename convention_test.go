@@ -0,0 +1,138 @@
+package hdur
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConvention_In(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Convention
+		d    Duration
+		unit Unit
+		want float64
+	}{
+		{"30 day month in days", Convention360, Months(1), UnitDay, 30},
+		{"360 day year in days", Convention360, Years(1), UnitDay, 360},
+		{"gregorian month in days", ConventionGregorian, Months(1), UnitDay, 30.436875},
+		{"gregorian year in hours", ConventionGregorian, Years(1), UnitHour, 365.2425 * 24},
+		{"julian year in days", ConventionJulian, Years(1), UnitDay, 365.25},
+		{"365 day year in weeks", Convention365, Years(1), UnitWeek, 365.0 / 7},
+		{"days in months", Convention360, Duration{Days: 45}, UnitMonth, 1.5},
+		{"days in years", Convention365, Duration{Days: 73}, UnitYear, 0.2},
+		{"months in years are exact", Convention360, Months(18), UnitYear, 1.5},
+		{"years in months are exact", ConventionGregorian, Years(2), UnitMonth, 24},
+		{"hours in months", Convention360, Duration{Hours: 36}, UnitMonth, 0.05},
+		{"mixed", Convention360, Duration{Months: 1, Days: 15, Hours: 12}, UnitDay, 45.5},
+		{"negative", Convention360, Duration{Years: -1, Months: -6}, UnitDay, -540},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.In(tt.d, tt.unit); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("In(%v, %v) = %v, want %v", tt.d, tt.unit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvention_ToStandard(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Convention
+		d    Duration
+		want time.Duration
+	}{
+		{"fixed units", Convention360, Duration{Days: 1, Hours: 2, Nanos: 3}, 26*time.Hour + 3},
+		{"30 day month", Convention360, Months(1), 30 * 24 * time.Hour},
+		{"gregorian year", ConventionGregorian, Years(1), 31556952 * time.Second},
+		{"negative", Convention365, Duration{Years: -1, Hours: -1}, -(365*24 + 1) * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.ToStandard(tt.d); got != tt.want {
+				t.Errorf("ToStandard(%v) = %v, want %v", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvention_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Convention
+		a    Duration
+		b    Duration
+		want int
+	}{
+		{"30 day month", Convention360, Months(1), Duration{Days: 30}, 0},
+		{"360 day year", Convention360, Years(1), Duration{Days: 360}, 0},
+		{"365 day year", Convention365, Years(1), Duration{Days: 365}, 0},
+		{"gregorian month", ConventionGregorian, Months(1), Duration{Days: 30}, 1},
+		{"gregorian century", ConventionGregorian, Years(100), Duration{Days: 36524, Hours: 6}, 0},
+		{"small difference", ConventionGregorian, Years(1), Duration{Years: 1, Nanos: 1}, -1},
+		{"opposite signs near the limit", Convention360, Duration{Days: 100000}, Duration{Days: -100000}, 1},
+		{"long and short", Convention360, Duration{Days: 200000, Nanos: 1}, Duration{Days: 200000}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("Compare(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.c.Compare(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Compare(%v, %v) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestConvention_Constructors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Duration
+		want Duration
+	}{
+		{"whole months", Convention360.Months(14), Duration{Years: 1, Months: 2}},
+		{"half month", Convention360.Months(1.5), Duration{Months: 1, Days: 15}},
+		{"negative half month", Convention360.Months(-0.5), Duration{Days: -15}},
+		{"gregorian half month", ConventionGregorian.Months(0.5), Duration{Days: 15, Hours: 5, Minutes: 14, Seconds: 33}},
+		{"year and a half", Convention360.Years(1.5), Duration{Years: 1, Months: 6}},
+		{"fraction of a month", Convention360.Years(0.1), Duration{Months: 1, Days: 6}},
+		{"365 day year fraction", Convention365.Years(1.0 / 365), Duration{Days: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultConvention(t *testing.T) {
+	defer func(c Convention) { DefaultConvention = c }(DefaultConvention)
+
+	d := Duration{Months: 1, Days: 15}
+	if got := d.InMonths(); math.Abs(got-(1+15/30.436875)) > 1e-9 {
+		t.Errorf("InMonths() = %v with the Gregorian convention", got)
+	}
+
+	DefaultConvention = Convention360
+	if got := d.InMonths(); got != 1.5 {
+		t.Errorf("InMonths() = %v, want 1.5", got)
+	}
+	if got := d.InHours(); got != 45*24 {
+		t.Errorf("InHours() = %v, want %v", got, 45*24)
+	}
+	if got := d.ToStandard(); got != 45*24*time.Hour {
+		t.Errorf("ToStandard() = %v, want %v", got, 45*24*time.Hour)
+	}
+	if got := Months(1).Compare(Duration{Days: 30}); got != 0 {
+		t.Errorf("Compare() = %d, want 0", got)
+	}
+}
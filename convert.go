@@ -1,16 +1,114 @@
 package hdur
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
 	"time"
 )
 
+// ErrOverflow is returned when a duration is too long to be represented as
+// a time.Duration, which holds about 292 years
+var ErrOverflow = errors.New("duration out of range for time.Duration")
+
 // ToStandard converts our Duration to a time.Duration
-// Note: This is an approximation as time.Duration doesn't handle months and years
+// Note: This is an approximation as time.Duration doesn't handle months and
+// years; they are converted using DefaultConvention. The result is undefined
+// for durations beyond the range of time.Duration; use ToStandardChecked or
+// ToStandardSaturating when that can happen.
 func (d Duration) ToStandard() time.Duration {
-	// Convert everything to nanoseconds
-	t := time.Now()
-	return d.Add(t).Sub(t)
+	return DefaultConvention.ToStandard(d)
+}
+
+// ToStandardChecked is like ToStandard but returns an error wrapping
+// ErrOverflow if the duration does not fit in a time.Duration
+func (d Duration) ToStandardChecked() (time.Duration, error) {
+	std, ok := DefaultConvention.toStandard(d)
+	if !ok {
+		return 0, fmt.Errorf("cannot convert %s: %w", d, ErrOverflow)
+	}
+	return std, nil
+}
+
+// ToStandardSaturating is like ToStandard but clamps durations that do not
+// fit in a time.Duration to math.MaxInt64 or math.MinInt64 nanoseconds
+func (d Duration) ToStandardSaturating() time.Duration {
+	std, _ := DefaultConvention.toStandard(d)
+	return std
+}
+
+// mulInt64 returns a*b and whether it did not overflow. b must be positive.
+func mulInt64(a, b int64) (int64, bool) {
+	p := a * b
+	return p, p/b == a
+}
+
+// addInt64 returns a+b and whether it did not overflow
+func addInt64(a, b int64) (int64, bool) {
+	s := a + b
+	return s, (b >= 0) == (s >= a)
+}
+
+// checkedFixedNanos is like fixedNanos but reports whether the total fits
+// in an int64
+func (d Duration) checkedFixedNanos() (int64, bool) {
+	components := []struct {
+		n    int
+		unit time.Duration
+	}{
+		{d.Days, 24 * time.Hour},
+		{d.Hours, time.Hour},
+		{d.Minutes, time.Minute},
+		{d.Seconds, time.Second},
+		{d.Nanos, time.Nanosecond},
+	}
+
+	var total int64
+	for _, c := range components {
+		n, ok := mulInt64(int64(c.n), int64(c.unit))
+		if !ok {
+			return 0, false
+		}
+		if total, ok = addInt64(total, n); !ok {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// floatNanos returns the total nanoseconds in the days through nanoseconds
+// of the duration as a float64, which cannot overflow
+func (d Duration) floatNanos() float64 {
+	return float64(d.Days)*dayNanos + float64(d.Hours)*float64(time.Hour) +
+		float64(d.Minutes)*float64(time.Minute) + float64(d.Seconds)*float64(time.Second) +
+		float64(d.Nanos)
+}
+
+// maxStandard is 2^63, the first float64 beyond the range of time.Duration
+const maxStandard = float64(math.MaxInt64)
+
+// ToStandardAt converts the duration to a time.Duration by applying it to
+// ref, so years and months have the length they have from that date:
+// Months(1).ToStandardAt(February 1, 2024) is 29 days.
+func (d Duration) ToStandardAt(ref time.Time) time.Duration {
+	return d.Add(ref).Sub(ref)
+}
+
+// ToStandardExact converts a duration without years or months to a
+// time.Duration, counting days as 24 hours. It returns an error for
+// durations with years or months, which have no fixed length, and one
+// wrapping ErrOverflow for durations that do not fit.
+func (d Duration) ToStandardExact() (time.Duration, error) {
+	d.normalize()
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("cannot convert %s to a time.Duration: years and months have no fixed length", d)
+	}
+	nanos, ok := d.checkedFixedNanos()
+	if !ok {
+		return 0, fmt.Errorf("cannot convert %s: %w", d, ErrOverflow)
+	}
+	return time.Duration(nanos), nil
 }
 
 // ToValue converts a duration string to a reflect.Value, perfect for use
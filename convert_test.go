@@ -1,8 +1,10 @@
 package hdur
 
 import (
+	"errors"
 	"math"
 	"testing"
+	"time"
 )
 
 func TestDuration_Conversion(t *testing.T) {
@@ -104,3 +106,145 @@ func TestDurationConversions(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_ToStandardAt(t *testing.T) {
+	feb := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		d    Duration
+		ref  time.Time
+		want time.Duration
+	}{
+		{"leap february", Months(1), feb, 29 * 24 * time.Hour},
+		{"march", Months(1), mar, 31 * 24 * time.Hour},
+		{"leap year", Years(1), feb, 366 * 24 * time.Hour},
+		{"common year", Years(1), mar.AddDate(-1, 0, 0), 365 * 24 * time.Hour},
+		{"fixed units", Duration{Days: 1, Hours: 2, Nanos: 3}, feb, 26*time.Hour + 3},
+		{"negative month", Months(-1), mar, -28 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.ToStandardAt(tt.ref); got != tt.want {
+				t.Errorf("ToStandardAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ToStandardExact(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{"zero", Duration{}, 0, false},
+		{"days count as 24 hours", Duration{Days: 2, Minutes: 30}, 48*time.Hour + 30*time.Minute, false},
+		{"negative", Duration{Hours: -1, Nanos: -5}, -time.Hour - 5, false},
+		{"months", Months(1), 0, true},
+		{"years", Duration{Years: 1, Days: 1}, 0, true},
+		{"calendar units cancel out", Duration{Years: 1, Months: -12, Days: 1}, 24 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.ToStandardExact()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToStandardExact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToStandardExact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ToStandardChecked(t *testing.T) {
+	tests := []struct {
+		name       string
+		d          Duration
+		want       time.Duration
+		saturating time.Duration
+		overflow   bool
+	}{
+		{"fixed units", Duration{Days: 1, Nanos: 1}, 24*time.Hour + 1, 24*time.Hour + 1, false},
+		{"gregorian year", Years(1), 31556952 * time.Second, 31556952 * time.Second, false},
+		{"largest duration", Duration{Nanos: math.MaxInt64}, math.MaxInt64, math.MaxInt64, false},
+		{"smallest duration", Duration{Nanos: math.MinInt64}, math.MinInt64, math.MinInt64, false},
+		{"past the largest duration", Duration{Seconds: 1, Nanos: math.MaxInt64 - 999999999}, 0, math.MaxInt64, true},
+		{"300 years", Years(300), 0, math.MaxInt64, true},
+		{"negative 300 years", Years(-300), 0, math.MinInt64, true},
+		{"too many days", Duration{Days: 200000}, 0, math.MaxInt64, true},
+		{"too many hours", Duration{Hours: math.MaxInt64 / 1000}, 0, math.MaxInt64, true},
+		{"negative with time", Duration{Years: -292, Days: -300}, 0, math.MinInt64, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.ToStandardChecked()
+			if tt.overflow {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("ToStandardChecked() error = %v, want ErrOverflow", err)
+				}
+			} else if err != nil || got != tt.want {
+				t.Errorf("ToStandardChecked() = %v, %v, want %v", got, err, tt.want)
+			}
+
+			if got := tt.d.ToStandardSaturating(); got != tt.saturating {
+				t.Errorf("ToStandardSaturating() = %v, want %v", got, tt.saturating)
+			}
+			if got := tt.d.InNanoseconds(); got != int64(tt.saturating) {
+				t.Errorf("InNanoseconds() = %v, want %v", got, int64(tt.saturating))
+			}
+		})
+	}
+
+	// Years and days of opposite signs may cancel out even though each part
+	// is out of range on its own
+	d := Duration{Years: 300, Days: -300 * 365}
+	got, err := d.ToStandardChecked()
+	if want := time.Duration(72.75 * 24 * float64(time.Hour)); err != nil || (got-want).Abs() > time.Microsecond {
+		t.Errorf("ToStandardChecked() = %v, %v, want about %v", got, err, want)
+	}
+}
+
+func TestDuration_Overflow(t *testing.T) {
+	d := MustParseDuration("1000 years")
+	if _, err := d.ToStandardChecked(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ToStandardChecked() error = %v, want ErrOverflow", err)
+	}
+	if _, err := (Duration{Days: 200000}).ToStandardExact(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ToStandardExact() error = %v, want ErrOverflow", err)
+	}
+
+	// Floating-point conversions do not wrap around
+	if got := (Duration{Days: 200000}).InHours(); got != 200000*24 {
+		t.Errorf("InHours() = %v, want %v", got, 200000*24)
+	}
+	if got := d.InYears(); got != 1000 {
+		t.Errorf("InYears() = %v, want 1000", got)
+	}
+
+	// Round and Truncate leave durations that do not fit unchanged
+	if got := d.Round(Hours(1)); got != d {
+		t.Errorf("Round() = %v, want %v", got, d)
+	}
+	if got := d.Truncate(Days(1)); got != d {
+		t.Errorf("Truncate() = %v, want %v", got, d)
+	}
+	if got := Hours(1).Truncate(d); got != Hours(1) {
+		t.Errorf("Truncate() = %v, want %v", got, Hours(1))
+	}
+
+	// A rounded result past the range is clamped
+	big := Duration{Nanos: math.MaxInt64 - 1}
+	if got := big.Round(Duration{Nanos: math.MaxInt64 / 2}); got.InNanoseconds() != math.MaxInt64-1 {
+		t.Errorf("Round() = %v, want the largest multiple", got)
+	}
+	if got := big.Round(Years(150)); got.InNanoseconds() != math.MaxInt64 {
+		t.Errorf("Round() = %v, want it clamped", got)
+	}
+}
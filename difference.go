@@ -0,0 +1,161 @@
+package hdur
+
+import (
+	"fmt"
+	"time"
+)
+
+// DiffOptions selects the components Difference returns and how the
+// smallest one is rounded
+type DiffOptions struct {
+	// LargestUnit is the largest component of the result; larger units are
+	// expressed in it. The zero value means UnitYear.
+	LargestUnit Unit
+
+	// SmallestUnit is the smallest component of the result; the remainder
+	// is rounded into it. The zero value means UnitNanosecond.
+	SmallestUnit Unit
+
+	// RoundingIncrement rounds the smallest component to a multiple of this
+	// many units. The zero value means 1.
+	RoundingIncrement int
+
+	// RoundingMode selects how the smallest component is rounded. The zero
+	// value truncates like RoundTrunc, counting whole units only.
+	RoundingMode RoundingMode
+}
+
+// maxIncrements holds the number of each time unit in the next larger unit.
+// A rounding increment for these units must divide it evenly when the
+// result has larger components.
+var maxIncrements = map[Unit]int{
+	UnitNanosecond:  1000,
+	UnitMicrosecond: 1000,
+	UnitMillisecond: 1000,
+	UnitSecond:      60,
+	UnitMinute:      60,
+	UnitHour:        24,
+}
+
+// Difference returns the duration from t2 to t1, like Sub, limited to the
+// components between opts.LargestUnit and opts.SmallestUnit:
+//
+//	// Whole weeks between two dates
+//	hdur.Difference(end, start, hdur.DiffOptions{
+//		LargestUnit:  hdur.UnitWeek,
+//		SmallestUnit: hdur.UnitWeek,
+//	})
+//
+// Larger units are expressed in the largest one, so with a LargestUnit of
+// UnitHour, two days are 48 hours. Units from hours down are measured as
+// elapsed time. Units from days up are measured on the wall clock of t1's
+// location as in Sub, and the result added to t2 gives t1 back when nothing
+// is rounded. Duration has no weeks component, so weeks are returned as
+// days, and units smaller than a second are all returned in Nanos.
+//
+// The remainder below SmallestUnit is rounded relative to t2, so rounding to
+// months measures against the actual calendar month. When rounding carries
+// into a larger unit the result is balanced again: 30 days 23 hours from
+// January 1 rounded to days is one month.
+func Difference(t1, t2 time.Time, opts DiffOptions) (Duration, error) {
+	largest, smallest := opts.LargestUnit, opts.SmallestUnit
+	if largest == 0 {
+		largest = UnitYear
+	}
+	if smallest == 0 {
+		smallest = UnitNanosecond
+	}
+	increment := opts.RoundingIncrement
+	if increment == 0 {
+		increment = 1
+	}
+
+	switch {
+	case !largest.valid():
+		return Duration{}, fmt.Errorf("invalid largest unit: %v", largest)
+	case !smallest.valid():
+		return Duration{}, fmt.Errorf("invalid smallest unit: %v", smallest)
+	case largest < smallest:
+		return Duration{}, fmt.Errorf("largest unit %v is smaller than smallest unit %v", largest, smallest)
+	case increment < 1:
+		return Duration{}, fmt.Errorf("invalid rounding increment: %d", increment)
+	}
+	if limit, ok := maxIncrements[smallest]; ok && largest > smallest && (increment >= limit || limit%increment != 0) {
+		return Duration{}, fmt.Errorf("rounding increment %d does not divide a %v evenly", increment, smallest+1)
+	}
+
+	t2 = t2.In(t1.Location())
+	if t1.Before(t2) {
+		// Measure forward from the earlier time and negate, rounding toward
+		// the same infinity as the negated result would
+		mode := opts.RoundingMode
+		switch mode {
+		case RoundFloor:
+			mode = RoundCeil
+		case RoundCeil:
+			mode = RoundFloor
+		}
+		return difference(t2, t1, largest, smallest, increment, mode).Neg(), nil
+	}
+	return difference(t1, t2, largest, smallest, increment, opts.RoundingMode), nil
+}
+
+// difference returns the duration from b to a, where a is not before b and
+// the options have been validated
+func difference(a, b time.Time, largest, smallest Unit, increment int, mode RoundingMode) Duration {
+	rounding := smallest != UnitNanosecond || increment != 1
+
+	if largest <= UnitHour {
+		total := int64(a.Sub(b))
+		if rounding {
+			total = roundInt64(total, smallest.nanos()*int64(increment), mode)
+		}
+		return splitNanos(total, largest)
+	}
+
+	d := balanceDifference(Sub(a, b), b, largest)
+	if rounding {
+		// Round relative to b, then measure again so that a remainder
+		// rounded up carries into the larger units
+		a = d.RoundTo(smallest, increment, mode, b).Add(b)
+		d = balanceDifference(Sub(a, b), b, largest)
+	}
+	return d
+}
+
+// balanceDifference expresses the years and months of d, measured from b,
+// in largest when it is smaller than a year
+func balanceDifference(d Duration, b time.Time, largest Unit) Duration {
+	switch largest {
+	case UnitYear:
+	case UnitMonth:
+		d.Months += d.Years * 12
+		d.Years = 0
+	default:
+		// Count the days the years and months span from b
+		mid := Duration{Years: d.Years, Months: d.Months}.Add(b)
+		d.Days += int(civilDate(mid).Sub(civilDate(b)) / (24 * time.Hour))
+		d.Years, d.Months = 0, 0
+	}
+	return d
+}
+
+// splitNanos splits n nanoseconds into components no larger than largest,
+// without carrying into days
+func splitNanos(n int64, largest Unit) Duration {
+	var d Duration
+	if largest >= UnitHour {
+		d.Hours = int(n / int64(time.Hour))
+		n %= int64(time.Hour)
+	}
+	if largest >= UnitMinute {
+		d.Minutes = int(n / int64(time.Minute))
+		n %= int64(time.Minute)
+	}
+	if largest >= UnitSecond {
+		d.Seconds = int(n / int64(time.Second))
+		n %= int64(time.Second)
+	}
+	d.Nanos = int(n)
+	return d
+}
@@ -0,0 +1,124 @@
+package hdur
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDifference(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar15 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t1   time.Time
+		t2   time.Time
+		opts DiffOptions
+		want Duration
+	}{
+		{"defaults match Sub", mar15, jan1, DiffOptions{}, Duration{Months: 2, Days: 14, Hours: 12}},
+		{"largest month", mar15, jan1, DiffOptions{LargestUnit: UnitMonth}, Duration{Months: 2, Days: 14, Hours: 12}},
+		{"largest day", mar15, jan1, DiffOptions{LargestUnit: UnitDay}, Duration{Days: 74, Hours: 12}},
+		{"total hours", mar15, jan1, DiffOptions{LargestUnit: UnitHour}, Duration{Hours: 1788}},
+		{"total minutes", mar15, jan1, DiffOptions{LargestUnit: UnitMinute}, Duration{Minutes: 107280}},
+		{"whole weeks by default", mar15, jan1, DiffOptions{LargestUnit: UnitWeek, SmallestUnit: UnitWeek}, Duration{Days: 70}},
+		{"nearest week", mar15, jan1, DiffOptions{LargestUnit: UnitWeek, SmallestUnit: UnitWeek, RoundingMode: RoundHalfExpand}, Duration{Days: 77}},
+		{"months and nearest days", mar15, jan1, DiffOptions{LargestUnit: UnitMonth, SmallestUnit: UnitDay, RoundingMode: RoundHalfExpand}, Duration{Months: 2, Days: 15}},
+		{"months and whole days", mar15, jan1, DiffOptions{LargestUnit: UnitMonth, SmallestUnit: UnitDay, RoundingMode: RoundTrunc}, Duration{Months: 2, Days: 14}},
+		{"nearest month in march", mar15, jan1, DiffOptions{SmallestUnit: UnitMonth, RoundingMode: RoundHalfExpand}, Duration{Months: 2}},
+		{"months rounded up", mar15, jan1, DiffOptions{SmallestUnit: UnitMonth, RoundingMode: RoundCeil}, Duration{Months: 3}},
+		{"whole years", mar15, jan1, DiffOptions{SmallestUnit: UnitYear, RoundingMode: RoundTrunc}, Duration{}},
+		{
+			name: "minute increment",
+			t1:   time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC),
+			t2:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			opts: DiffOptions{SmallestUnit: UnitMinute, RoundingIncrement: 15, RoundingMode: RoundHalfExpand},
+			want: Duration{Minutes: 15},
+		},
+		{
+			name: "rounding carries into months",
+			t1:   time.Date(2024, 1, 31, 23, 59, 59, 900000000, time.UTC),
+			t2:   jan1,
+			opts: DiffOptions{SmallestUnit: UnitSecond, RoundingMode: RoundHalfExpand},
+			want: Duration{Months: 1},
+		},
+		{
+			name: "rounding carries into a day",
+			t1:   time.Date(2024, 1, 1, 23, 45, 0, 0, time.UTC),
+			t2:   jan1,
+			opts: DiffOptions{LargestUnit: UnitDay, SmallestUnit: UnitHour, RoundingMode: RoundHalfExpand},
+			want: Duration{Days: 1},
+		},
+		{
+			name: "milliseconds",
+			t1:   jan1.Add(1500 * time.Millisecond),
+			t2:   jan1,
+			opts: DiffOptions{LargestUnit: UnitMillisecond, SmallestUnit: UnitMillisecond},
+			want: Duration{Nanos: 1500000000},
+		},
+		{"negative", jan1, mar15, DiffOptions{}, Duration{Months: -2, Days: -14, Hours: -12}},
+		{"negative truncated by default", jan1, mar15, DiffOptions{LargestUnit: UnitMonth, SmallestUnit: UnitDay}, Duration{Months: -2, Days: -14}},
+		{"negative floor", jan1, mar15, DiffOptions{LargestUnit: UnitMonth, SmallestUnit: UnitDay, RoundingMode: RoundFloor}, Duration{Months: -2, Days: -15}},
+		{"negative ceil", jan1, mar15, DiffOptions{LargestUnit: UnitMonth, SmallestUnit: UnitDay, RoundingMode: RoundCeil}, Duration{Months: -2, Days: -14}},
+		{"negative total hours", jan1, mar15, DiffOptions{LargestUnit: UnitHour}, Duration{Hours: -1788}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Difference(tt.t1, tt.t2, tt.opts)
+			if err != nil {
+				t.Fatalf("Difference() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Difference() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDifference_Errors(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		opts DiffOptions
+	}{
+		{"largest below smallest", DiffOptions{LargestUnit: UnitDay, SmallestUnit: UnitMonth}},
+		{"invalid largest unit", DiffOptions{LargestUnit: Unit(42)}},
+		{"invalid smallest unit", DiffOptions{SmallestUnit: Unit(-1)}},
+		{"negative increment", DiffOptions{RoundingIncrement: -1}},
+		{"increment does not divide an hour", DiffOptions{SmallestUnit: UnitMinute, RoundingIncrement: 7}},
+		{"increment is a whole day", DiffOptions{SmallestUnit: UnitHour, RoundingIncrement: 24}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Difference(now, now, tt.opts); err == nil {
+				t.Error("Difference() error = nil, want an error")
+			}
+		})
+	}
+
+	// Increments only need to divide the next unit when it is in the result
+	opts := DiffOptions{LargestUnit: UnitMinute, SmallestUnit: UnitMinute, RoundingIncrement: 7}
+	if got, err := Difference(now.Add(20*time.Minute), now, opts); err != nil || got != (Duration{Minutes: 14}) {
+		t.Errorf("Difference() = %v, %v, want 14m", got, err)
+	}
+}
+
+func TestDifference_AddInverse(t *testing.T) {
+	start := time.Date(2023, time.December, 15, 18, 30, 0, 0, time.UTC)
+	units := []Unit{UnitYear, UnitMonth, UnitWeek, UnitDay, UnitHour, UnitSecond}
+
+	for step := 0; step < 24*500; step += 7 {
+		end := start.Add(time.Duration(step) * time.Hour)
+		for _, unit := range units {
+			d, err := Difference(end, start, DiffOptions{LargestUnit: unit})
+			if err != nil {
+				t.Fatalf("Difference() error = %v", err)
+			}
+			if got := d.Add(start); !got.Equal(end) {
+				t.Fatalf("Difference(%v, %v, %v) = %v, which adds back to %v", end, start, unit, d, got)
+			}
+		}
+	}
+}
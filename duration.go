@@ -57,6 +57,11 @@ type Duration struct {
 	Nanos   int
 }
 
+// components returns the duration components from largest to smallest
+func (d Duration) components() [7]int {
+	return [7]int{d.Years, d.Months, d.Days, d.Hours, d.Minutes, d.Seconds, d.Nanos}
+}
+
 // isNegativeDuration checks if the duration is negative by examining
 // the first non-zero component in order of significance
 func (d *Duration) isNegativeDuration() bool {
@@ -80,50 +85,105 @@ func (d *Duration) isNegativeDuration() bool {
 	}
 }
 
-// makePositive makes all components of the duration positive
-func (d *Duration) makePositive() {
-	d.Years = abs(d.Years)
-	d.Months = abs(d.Months)
-	d.Days = abs(d.Days)
-	d.Hours = abs(d.Hours)
-	d.Minutes = abs(d.Minutes)
-	d.Seconds = abs(d.Seconds)
-	d.Nanos = abs(d.Nanos)
+// negate flips the sign of every component of the duration
+func (d *Duration) negate() {
+	d.Years = -d.Years
+	d.Months = -d.Months
+	d.Days = -d.Days
+	d.Hours = -d.Hours
+	d.Minutes = -d.Minutes
+	d.Seconds = -d.Seconds
+	d.Nanos = -d.Nanos
+}
+
+// floorDivMod returns the floored quotient and the non-negative remainder
+// of x divided by a positive n
+func floorDivMod(x, n int) (int, int) {
+	q, r := x/n, x%n
+	if r < 0 {
+		q--
+		r += n
+	}
+	return q, r
+}
+
+// hasMixedSigns reports whether the values contain both positive and
+// negative numbers
+func hasMixedSigns(values ...int) bool {
+	positive, negative := false, false
+	for _, v := range values {
+		positive = positive || v > 0
+		negative = negative || v < 0
+	}
+	return positive && negative
+}
+
+// balanceMonths gives years and months a common sign when they differ.
+// A year is always twelve months, so this never changes the duration.
+func (d *Duration) balanceMonths() {
+	if !hasMixedSigns(d.Years, d.Months) {
+		return
+	}
+
+	carry, months := floorDivMod(d.Months, 12)
+	d.Years += carry
+	d.Months = months
+	if d.Years < 0 && d.Months > 0 {
+		d.Years++
+		d.Months -= 12
+	}
 }
 
-// makeNegative makes all components of the duration negative
-func (d *Duration) makeNegative() {
-	d.Years = -abs(d.Years)
-	d.Months = -abs(d.Months)
-	d.Days = -abs(d.Days)
-	d.Hours = -abs(d.Hours)
-	d.Minutes = -abs(d.Minutes)
-	d.Seconds = -abs(d.Seconds)
-	d.Nanos = -abs(d.Nanos)
+// balanceTime gives hours, minutes, seconds and nanoseconds a common sign
+// when they differ. These units always have a fixed length relative to each
+// other, so this never changes the duration.
+func (d *Duration) balanceTime() {
+	if !hasMixedSigns(d.Hours, d.Minutes, d.Seconds, d.Nanos) {
+		return
+	}
+
+	// Carry everything into hours so the smaller units are non-negative
+	var carry int
+	carry, d.Nanos = floorDivMod(d.Nanos, 1000000000)
+	d.Seconds += carry
+	carry, d.Seconds = floorDivMod(d.Seconds, 60)
+	d.Minutes += carry
+	carry, d.Minutes = floorDivMod(d.Minutes, 60)
+	d.Hours += carry
+
+	// Borrow an hour if the hours are negative but the rest is not
+	if d.Hours < 0 && (d.Minutes > 0 || d.Seconds > 0 || d.Nanos > 0) {
+		d.Hours++
+		rest := int64(d.Minutes)*60000000000 + int64(d.Seconds)*1000000000 + int64(d.Nanos) - 3600000000000
+		d.Minutes = int(rest / 60000000000)
+		d.Seconds = int(rest % 60000000000 / 1000000000)
+		d.Nanos = int(rest % 1000000000)
+	}
 }
 
-// normalizeTimeUnits normalizes time units from smallest to largest
+// normalizeTimeUnits normalizes time units from smallest to largest,
+// carrying whole multiples of the next larger unit whatever their sign
 func (d *Duration) normalizeTimeUnits() {
 	// Handle nanoseconds overflow
-	if d.Nanos >= 1000000000 {
+	if d.Nanos >= 1000000000 || d.Nanos <= -1000000000 {
 		d.Seconds += d.Nanos / 1000000000
 		d.Nanos = d.Nanos % 1000000000
 	}
 
 	// Handle seconds overflow
-	if d.Seconds >= 60 {
+	if d.Seconds >= 60 || d.Seconds <= -60 {
 		d.Minutes += d.Seconds / 60
 		d.Seconds = d.Seconds % 60
 	}
 
 	// Handle minutes overflow
-	if d.Minutes >= 60 {
+	if d.Minutes >= 60 || d.Minutes <= -60 {
 		d.Hours += d.Minutes / 60
 		d.Minutes = d.Minutes % 60
 	}
 
 	// Handle hours overflow
-	if d.Hours >= 24 {
+	if d.Hours >= 24 || d.Hours <= -24 {
 		d.Days += d.Hours / 24
 		d.Hours = d.Hours % 24
 	}
@@ -131,30 +191,44 @@ func (d *Duration) normalizeTimeUnits() {
 
 // normalizeMonthsToYears normalizes months to years
 func (d *Duration) normalizeMonthsToYears() {
-	if d.Months >= 12 {
+	if d.Months >= 12 || d.Months <= -12 {
 		d.Years += d.Months / 12
 		d.Months = d.Months % 12
 	}
 }
 
-// normalize ensures all duration components are within their natural ranges
+// normalize ensures all duration components are within their natural ranges.
+//
+// Years and months are balanced to a common sign, as are hours through
+// nanoseconds, since those conversions are exact. Whole 24-hour blocks are
+// always carried into days, whichever sign the hours have, so "-1 day 30
+// hours" becomes "6 hours" just as "1 day 30 hours" becomes "2 days 6 hours".
+// Days are never borrowed against months or hours, so a duration such as
+// "1 month -1 day" or "-1 day 6 hours" keeps components of different signs;
+// its sign is that of its most significant component.
 func (d *Duration) normalize() {
-	// First, determine if the duration is negative
-	isNegative := d.isNegativeDuration()
-
-	// Make all components positive for normalization
-	if isNegative {
-		d.makePositive()
-	}
+	d.balanceMonths()
+	d.balanceTime()
 
-	// Normalize all time units
+	// Once balanced, the units carried into each other share a sign, so
+	// carrying toward zero works the same for negative durations
 	d.normalizeTimeUnits()
-
-	// Normalize months to years
 	d.normalizeMonthsToYears()
+}
 
-	// Restore negative sign if needed
-	if isNegative {
-		d.makeNegative()
+// BalanceDays returns the duration with every daysPerMonth days folded into
+// a month, for callers that want a fixed month length:
+//
+//	Days(45).BalanceDays(30) // 1mo 15d
+//
+// Days are otherwise never converted to months. BalanceDays panics if
+// daysPerMonth is less than one.
+func (d Duration) BalanceDays(daysPerMonth int) Duration {
+	if daysPerMonth < 1 {
+		panic("invalid days per month")
 	}
+	d.Months += d.Days / daysPerMonth
+	d.Days %= daysPerMonth
+	d.normalize()
+	return d
 }
@@ -91,3 +91,101 @@ func TestDuration_Normalize(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_Normalize_DayCarry(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Duration
+		want  Duration
+	}{
+		{"positive days and hours", Duration{Days: 1, Hours: 30}, Duration{Days: 2, Hours: 6}},
+		{"negative days and hours", Duration{Days: -1, Hours: -30}, Duration{Days: -2, Hours: -6}},
+		{"negative days, positive hours", Duration{Days: -1, Hours: 30}, Duration{Hours: 6}},
+		{"positive days, negative hours", Duration{Days: 1, Hours: -30}, Duration{Hours: -6}},
+		{"hours left against days", Duration{Days: -3, Hours: 30}, Duration{Days: -2, Hours: 6}},
+		{"minutes against days", Duration{Days: 1, Minutes: -90}, Duration{Days: 1, Hours: -1, Minutes: -30}},
+		{"under a day", Duration{Days: -1, Hours: 23}, Duration{Days: -1, Hours: 23}},
+		{"months against hours", Duration{Months: 1, Hours: -49}, Duration{Months: 1, Days: -2, Hours: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.input
+			got.normalize()
+			if got != tt.want {
+				t.Errorf("normalize(%#v) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzDuration_NormalizePreservesValue(f *testing.F) {
+	f.Add(int32(1), int32(-13), int32(5), int32(-1), int32(30), int32(-90), int32(5))
+	f.Add(int32(-1), int32(1), int32(-1), int32(1), int32(-1), int32(1), int32(-1))
+
+	f.Fuzz(func(t *testing.T, years, months, days, hours, minutes, seconds, nanos int32) {
+		d := Duration{
+			Years:   int(years),
+			Months:  int(months),
+			Days:    int(days),
+			Hours:   int(hours),
+			Minutes: int(minutes),
+			Seconds: int(seconds),
+			Nanos:   int(nanos),
+		}
+		got := d
+		got.normalize()
+
+		if got.Years*12+got.Months != d.Years*12+d.Months {
+			t.Errorf("normalize(%#v) changed months: %#v", d, got)
+		}
+		if got.fixedNanos() != d.fixedNanos() {
+			t.Errorf("normalize(%#v) changed fixed length: %#v", d, got)
+		}
+
+		again := got
+		again.normalize()
+		if again != got {
+			t.Errorf("normalize is not idempotent: %#v then %#v", got, again)
+		}
+
+		neg := d
+		neg.negate()
+		neg.normalize()
+		neg.negate()
+		if neg != got {
+			t.Errorf("normalize is not symmetric: %#v negated normalizes to %#v", d, neg)
+		}
+	})
+}
+
+func TestDuration_BalanceDays(t *testing.T) {
+	tests := []struct {
+		name         string
+		d            Duration
+		daysPerMonth int
+		want         Duration
+	}{
+		{"below a month", Days(25), 30, Duration{Days: 25}},
+		{"above a month", Days(45), 30, Duration{Months: 1, Days: 15}},
+		{"into years", Days(365), 30, Duration{Years: 1, Days: 5}},
+		{"negative", Days(-45), 30, Duration{Months: -1, Days: -15}},
+		{"keeps other units", Duration{Months: 1, Days: 31, Hours: 2}, 31, Duration{Months: 2, Hours: 2}},
+		{"mixed signs", Duration{Months: 2, Days: -45}, 30, Duration{Months: 1, Days: -15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.BalanceDays(tt.daysPerMonth); got != tt.want {
+				t.Errorf("BalanceDays(%d) = %#v, want %#v", tt.daysPerMonth, got, tt.want)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("BalanceDays(0) did not panic")
+		}
+	}()
+	Days(1).BalanceDays(0)
+}
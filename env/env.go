@@ -0,0 +1,155 @@
+/*
+package env populates duration fields of a struct from environment variables.
+
+Fields are selected with an env tag naming the variable, and may provide a
+default used when the variable is unset or empty:
+
+	type Config struct {
+		CacheTTL    hdur.Duration   `env:"CACHE_TTL" default:"15m"`
+		Retention   *hdur.Duration  `env:"RETENTION"`
+		Backoff     []hdur.Duration `env:"BACKOFF" default:"1s,5s,30s"`
+		ReadTimeout time.Duration   `env:"READ_TIMEOUT" default:"30 seconds"`
+	}
+
+	var cfg Config
+	if err := env.Load(&cfg); err != nil {
+		log.Fatal(err)
+	}
+
+Supported field types are hdur.Duration, time.Duration, pointers to either,
+and slices of either read from comma-separated lists. Values are parsed with
+hdur.ParseDuration, so time.Duration fields accept human-readable input too,
+but not years or months, which have no fixed length. Nested structs are
+loaded recursively. Fields left unset keep their current value.
+*/
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/watzon/hdur"
+)
+
+// FieldError describes a failure to load a single struct field
+type FieldError struct {
+	Field string // Field is the dotted path of the struct field
+	Var   string // Var is the environment variable name
+	Value string // Value is the raw value that failed to parse
+	Err   error
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (%s=%q): %v", e.Field, e.Var, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	durationType    = reflect.TypeOf(hdur.Duration{})
+	stdDurationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Load populates the tagged fields of the struct pointed to by cfg from the
+// environment. All failures are reported together, joined with errors.Join,
+// and each can be inspected with errors.As as a *FieldError.
+func Load(cfg interface{}) error {
+	return LoadFrom(cfg, os.LookupEnv)
+}
+
+// LoadFrom is like Load but reads variables through lookup instead of the
+// process environment
+func LoadFrom(cfg interface{}, lookup func(string) (string, bool)) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: Load requires a non-nil pointer to a struct, got %T", cfg)
+	}
+
+	var errs []error
+	loadStruct(v.Elem(), "", lookup, &errs)
+	return errors.Join(errs...)
+}
+
+// loadStruct loads every tagged field of v, recursing into untagged structs
+func loadStruct(v reflect.Value, prefix string, lookup func(string) (string, bool), errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + field.Name
+		name, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				loadStruct(v.Field(i), path+".", lookup, errs)
+			}
+			continue
+		}
+
+		value, ok := lookup(name)
+		if !ok || value == "" {
+			value, ok = field.Tag.Lookup("default")
+		}
+		if !ok {
+			continue
+		}
+
+		if err := setField(v.Field(i), value); err != nil {
+			*errs = append(*errs, &FieldError{Field: path, Var: name, Value: value, Err: err})
+		}
+	}
+}
+
+// setField parses value into a supported field
+func setField(field reflect.Value, value string) error {
+	switch t := field.Type(); {
+	case t.Kind() == reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		if err := setField(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case t.Kind() == reflect.Slice:
+		parts := strings.Split(value, ",")
+		slice := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			if err := setField(slice.Index(i), part); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	if field.Type() != durationType && field.Type() != stdDurationType {
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	d, err := hdur.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	if field.Type() == durationType {
+		field.Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	std, err := d.ToStandardExact()
+	if err != nil {
+		return err
+	}
+	field.SetInt(int64(std))
+	return nil
+}
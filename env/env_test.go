@@ -0,0 +1,129 @@
+package env
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/watzon/hdur"
+)
+
+// lookupMap returns a lookup function reading from vars
+func lookupMap(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+type serverConfig struct {
+	ReadTimeout  time.Duration  `env:"READ_TIMEOUT" default:"30 seconds"`
+	WriteTimeout *time.Duration `env:"WRITE_TIMEOUT"`
+}
+
+type config struct {
+	CacheTTL  hdur.Duration   `env:"CACHE_TTL" default:"15m"`
+	Retention *hdur.Duration  `env:"RETENTION"`
+	Backoff   []hdur.Duration `env:"BACKOFF" default:"1s,5s,30s"`
+	Unset     hdur.Duration   `env:"UNSET"`
+	Ignored   hdur.Duration
+	Server    serverConfig
+	internal  hdur.Duration `env:"INTERNAL"`
+}
+
+func TestLoadFrom(t *testing.T) {
+	vars := map[string]string{
+		"RETENTION":     "1 year 6 months",
+		"BACKOFF":       "",
+		"WRITE_TIMEOUT": "1m 30s",
+		"INTERNAL":      "1h",
+	}
+
+	cfg := config{Unset: hdur.Hours(2), Ignored: hdur.Hours(3)}
+	if err := LoadFrom(&cfg, lookupMap(vars)); err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+
+	if cfg.CacheTTL != hdur.Minutes(15) {
+		t.Errorf("CacheTTL = %v, want 15m", cfg.CacheTTL)
+	}
+	if cfg.Retention == nil || *cfg.Retention != (hdur.Duration{Years: 1, Months: 6}) {
+		t.Errorf("Retention = %v, want 1y 6mo", cfg.Retention)
+	}
+	if want := []hdur.Duration{hdur.Seconds(1), hdur.Seconds(5), hdur.Seconds(30)}; !reflect.DeepEqual(cfg.Backoff, want) {
+		t.Errorf("Backoff = %v, want %v", cfg.Backoff, want)
+	}
+	if cfg.Unset != hdur.Hours(2) || cfg.Ignored != hdur.Hours(3) || !cfg.internal.IsZero() {
+		t.Errorf("untouched fields changed: %+v", cfg)
+	}
+	if cfg.Server.ReadTimeout != 30*time.Second {
+		t.Errorf("Server.ReadTimeout = %v, want 30s", cfg.Server.ReadTimeout)
+	}
+	if cfg.Server.WriteTimeout == nil || *cfg.Server.WriteTimeout != 90*time.Second {
+		t.Errorf("Server.WriteTimeout = %v, want 1m30s", cfg.Server.WriteTimeout)
+	}
+}
+
+func TestLoadFrom_Errors(t *testing.T) {
+	vars := map[string]string{
+		"CACHE_TTL":     "soon",
+		"BACKOFF":       "1s,later",
+		"READ_TIMEOUT":  "1 month",
+		"WRITE_TIMEOUT": "forever",
+	}
+
+	var cfg config
+	err := LoadFrom(&cfg, lookupMap(vars))
+	if err == nil {
+		t.Fatal("LoadFrom() expected error")
+	}
+
+	for _, want := range []string{
+		`CacheTTL (CACHE_TTL="soon")`,
+		`Backoff (BACKOFF="1s,later"): item 1`,
+		`Server.ReadTimeout (READ_TIMEOUT="1 month")`,
+		`Server.WriteTimeout (WRITE_TIMEOUT="forever")`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "CacheTTL" || fieldErr.Var != "CACHE_TTL" {
+		t.Errorf("errors.As() = %+v", fieldErr)
+	}
+}
+
+func TestLoadFrom_InvalidTargets(t *testing.T) {
+	var notStruct int
+	var nilConfig *config
+	for _, target := range []interface{}{config{}, &notStruct, nilConfig, nil} {
+		if err := LoadFrom(target, lookupMap(nil)); err == nil {
+			t.Errorf("LoadFrom(%T) expected error", target)
+		}
+	}
+
+	var unsupported struct {
+		Count int `env:"COUNT" default:"5"`
+	}
+	if err := LoadFrom(&unsupported, lookupMap(nil)); err == nil || !strings.Contains(err.Error(), "unsupported field type int") {
+		t.Errorf("LoadFrom() error = %v, want unsupported field type", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("HDUR_ENV_TEST_TTL", "2 days")
+
+	var cfg struct {
+		TTL hdur.Duration `env:"HDUR_ENV_TEST_TTL"`
+	}
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.TTL != hdur.Days(2) {
+		t.Errorf("TTL = %v, want 2d", cfg.TTL)
+	}
+}
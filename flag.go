@@ -0,0 +1,143 @@
+package hdur
+
+import (
+	"flag"
+	"strings"
+)
+
+// Set implements flag.Value, parsing s with ParseDuration. Together with
+// String and Type, *Duration also satisfies spf13/pflag's Value interface:
+//
+//	var retention hdur.Duration
+//	flag.Var(&retention, "retention", "how long to keep backups")
+func (d *Duration) Set(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
+
+// Type returns the flag type name shown in pflag usage output
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// FlagVar defines a Duration flag with the specified name, default value and
+// usage string on flag.CommandLine. The argument p points to a Duration
+// variable in which to store the value of the flag.
+func FlagVar(p *Duration, name string, value Duration, usage string) {
+	*p = value
+	flag.Var(p, name, usage)
+}
+
+// Flag defines a Duration flag with the specified name, default value and
+// usage string on flag.CommandLine, mirroring flag.Duration. The return value
+// is the address of a Duration variable that stores the value of the flag.
+func Flag(name string, value Duration, usage string) *Duration {
+	p := new(Duration)
+	FlagVar(p, name, value, usage)
+	return p
+}
+
+// SliceValue is a flag.Value that collects durations from repeated flags or
+// comma-separated lists ("--ttl 1h,2h --ttl 3h"). The first value given on
+// the command line replaces the default. It also satisfies pflag's Value and
+// SliceValue interfaces.
+type SliceValue struct {
+	values  *[]Duration
+	changed bool
+}
+
+// NewSliceValue returns a SliceValue storing into p, initialized to value
+func NewSliceValue(p *[]Duration, value []Duration) *SliceValue {
+	*p = append([]Duration(nil), value...)
+	return &SliceValue{values: p}
+}
+
+// parseList parses a comma-separated list of durations
+func parseList(s string) ([]Duration, error) {
+	parts := strings.Split(s, ",")
+	result := make([]Duration, 0, len(parts))
+	for _, part := range parts {
+		d, err := ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// Set implements flag.Value
+func (s *SliceValue) Set(value string) error {
+	parsed, err := parseList(value)
+	if err != nil {
+		return err
+	}
+
+	if !s.changed {
+		*s.values = parsed
+		s.changed = true
+	} else {
+		*s.values = append(*s.values, parsed...)
+	}
+	return nil
+}
+
+// String implements flag.Value
+func (s *SliceValue) String() string {
+	if s == nil || s.values == nil {
+		return "[]"
+	}
+	return "[" + strings.Join(s.GetSlice(), ",") + "]"
+}
+
+// Type returns the flag type name shown in pflag usage output
+func (s *SliceValue) Type() string {
+	return "durationSlice"
+}
+
+// Append adds a single duration to the slice
+func (s *SliceValue) Append(value string) error {
+	d, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*s.values = append(*s.values, d)
+	return nil
+}
+
+// Replace replaces the slice with the given durations
+func (s *SliceValue) Replace(values []string) error {
+	result := make([]Duration, 0, len(values))
+	for _, value := range values {
+		d, err := ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		result = append(result, d)
+	}
+	*s.values = result
+	return nil
+}
+
+// GetSlice returns the durations in their canonical string form
+func (s *SliceValue) GetSlice() []string {
+	result := make([]string, 0, len(*s.values))
+	for _, d := range *s.values {
+		result = append(result, d.Canonical())
+	}
+	return result
+}
+
+// SliceFlagVar defines a []Duration flag with the specified name, default
+// value and usage string on flag.CommandLine
+func SliceFlagVar(p *[]Duration, name string, value []Duration, usage string) {
+	flag.Var(NewSliceValue(p, value), name, usage)
+}
+
+// SliceFlag defines a []Duration flag with the specified name, default value
+// and usage string on flag.CommandLine. The return value is the address of a
+// []Duration variable that stores the value of the flag.
+func SliceFlag(name string, value []Duration, usage string) *[]Duration {
+	p := new([]Duration)
+	SliceFlagVar(p, name, value, usage)
+	return p
+}
@@ -0,0 +1,129 @@
+package hdur
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pflagValue mirrors spf13/pflag's Value interface
+type pflagValue interface {
+	flag.Value
+	Type() string
+}
+
+// pflagSliceValue mirrors spf13/pflag's SliceValue interface
+type pflagSliceValue interface {
+	Append(string) error
+	Replace([]string) error
+	GetSlice() []string
+}
+
+var (
+	_ pflagValue      = (*Duration)(nil)
+	_ pflagValue      = (*SliceValue)(nil)
+	_ pflagSliceValue = (*SliceValue)(nil)
+)
+
+// withCommandLine replaces flag.CommandLine for the duration of a test
+func withCommandLine(t *testing.T) {
+	t.Helper()
+	old := flag.CommandLine
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+	flag.CommandLine.SetOutput(io.Discard)
+	t.Cleanup(func() { flag.CommandLine = old })
+}
+
+func TestFlag(t *testing.T) {
+	withCommandLine(t)
+
+	retention := Flag("retention", Months(1), "retention period")
+	var timeout Duration
+	FlagVar(&timeout, "timeout", Seconds(30), "request timeout")
+
+	if !retention.Equal(Months(1)) || !timeout.Equal(Seconds(30)) {
+		t.Fatalf("defaults = %v, %v", retention, timeout)
+	}
+
+	if err := flag.CommandLine.Parse([]string{"-retention", "1 year 6 months"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if *retention != (Duration{Years: 1, Months: 6}) {
+		t.Errorf("retention = %v, want 1y 6mo", retention)
+	}
+	if !timeout.Equal(Seconds(30)) {
+		t.Errorf("timeout = %v, want 30s", timeout)
+	}
+
+	if err := flag.CommandLine.Parse([]string{"-timeout", "soon"}); err == nil {
+		t.Error("Parse() expected error for invalid duration")
+	}
+
+	var usage strings.Builder
+	flag.CommandLine.SetOutput(&usage)
+	flag.CommandLine.PrintDefaults()
+	if !strings.Contains(usage.String(), "(default 30s)") {
+		t.Errorf("PrintDefaults() = %q, want default value", usage.String())
+	}
+
+	if got := timeout.Type(); got != "duration" {
+		t.Errorf("Type() = %q, want duration", got)
+	}
+}
+
+func TestSliceFlag(t *testing.T) {
+	withCommandLine(t)
+
+	intervals := SliceFlag("interval", []Duration{Hours(1)}, "check intervals")
+	if !reflect.DeepEqual(*intervals, []Duration{Hours(1)}) {
+		t.Fatalf("default = %v", *intervals)
+	}
+
+	args := []string{"-interval", "5m,10m", "-interval", "1 day"}
+	if err := flag.CommandLine.Parse(args); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Duration{Minutes(5), Minutes(10), Days(1)}
+	if !reflect.DeepEqual(*intervals, want) {
+		t.Errorf("intervals = %v, want %v", *intervals, want)
+	}
+
+	value := flag.CommandLine.Lookup("interval").Value
+	if got := value.String(); got != "[5m,10m,1d]" {
+		t.Errorf("String() = %q, want [5m,10m,1d]", got)
+	}
+	if err := value.Set("1h,bogus"); err == nil {
+		t.Error("Set() expected error for invalid duration")
+	}
+}
+
+func TestSliceValue_PflagMethods(t *testing.T) {
+	var durations []Duration
+	v := NewSliceValue(&durations, nil)
+
+	if err := v.Replace([]string{"1h", "2h"}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if err := v.Append("30m"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := v.Append("never"); err == nil {
+		t.Error("Append() expected error for invalid duration")
+	}
+	if err := v.Replace([]string{"never"}); err == nil {
+		t.Error("Replace() expected error for invalid duration")
+	}
+
+	if got := v.GetSlice(); !reflect.DeepEqual(got, []string{"1h", "2h", "30m"}) {
+		t.Errorf("GetSlice() = %v", got)
+	}
+	if got := v.Type(); got != "durationSlice" {
+		t.Errorf("Type() = %q, want durationSlice", got)
+	}
+	if got := (&SliceValue{}).String(); got != "[]" {
+		t.Errorf("zero String() = %q, want []", got)
+	}
+}
@@ -1,10 +1,14 @@
 package hdur
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Format returns a string representation of the duration using the given format
@@ -48,68 +52,220 @@ func (d Duration) abs() Duration {
 	}
 }
 
-// formatMainUnits formats years through seconds
-func (d Duration) formatMainUnits() []string {
-	parts := []string{}
-	if d.Years > 0 {
-		parts = append(parts, fmt.Sprintf("%dy", d.Years))
-	}
-	if d.Months > 0 {
-		parts = append(parts, fmt.Sprintf("%dmo", d.Months))
-	}
-	if d.Days > 0 {
-		parts = append(parts, fmt.Sprintf("%dd", d.Days))
+// componentSign returns the sign written before a component of a duration:
+// none when it matches the leading sign of the duration, and "-" or "+" when
+// it differs, as ParseDuration carries the leading sign to later components
+func componentSign(n int, negative bool) string {
+	switch {
+	case n < 0 && !negative:
+		return "-"
+	case n > 0 && negative:
+		return "+"
+	default:
+		return ""
 	}
-	if d.Hours > 0 {
-		parts = append(parts, fmt.Sprintf("%dh", d.Hours))
+}
+
+// formatMainUnits formats years through seconds, signing components whose
+// sign differs from the leading one
+func (d Duration) formatMainUnits(negative bool) []string {
+	units := []struct {
+		value int
+		unit  string
+	}{
+		{d.Years, "y"},
+		{d.Months, "mo"},
+		{d.Days, "d"},
+		{d.Hours, "h"},
+		{d.Minutes, "m"},
 	}
-	if d.Minutes > 0 {
-		parts = append(parts, fmt.Sprintf("%dm", d.Minutes))
+
+	parts := []string{}
+	for _, u := range units {
+		if u.value != 0 {
+			parts = append(parts, canonicalComponent(u.value, u.unit, negative))
+		}
 	}
-	if d.Seconds > 0 || (d.Nanos > 0 && d.Nanos >= 1000000000) {
-		parts = append(parts, fmt.Sprintf("%ds", d.Seconds))
+	if d.Seconds != 0 || d.Nanos >= 1000000000 || d.Nanos <= -1000000000 {
+		parts = append(parts, canonicalComponent(d.Seconds, "s", negative))
 	}
 	return parts
 }
 
-// formatNanos formats sub-second units
-func (d Duration) formatNanos() string {
-	nanos := d.Nanos
+// formatNanos formats sub-second units, signing them if their sign differs
+// from the leading one
+func (d Duration) formatNanos(negative bool) string {
+	nanos := abs(d.Nanos)
 	if nanos == 0 {
 		return ""
 	}
 
+	sign := componentSign(d.Nanos, negative)
 	switch {
 	case nanos >= 1000000:
 		if nanos%1000000 == 0 {
-			return fmt.Sprintf("%dms", nanos/1000000)
+			return fmt.Sprintf("%s%dms", sign, nanos/1000000)
 		}
-		return fmt.Sprintf("%.3fms", float64(nanos)/1000000.0)
+		return fmt.Sprintf("%s%.3fms", sign, float64(nanos)/1000000.0)
 	case nanos >= 1000:
 		if nanos%1000 == 0 {
-			return fmt.Sprintf("%dµs", nanos/1000)
+			return fmt.Sprintf("%s%dµs", sign, nanos/1000)
 		}
-		return fmt.Sprintf("%.3fµs", float64(nanos)/1000.0)
+		return fmt.Sprintf("%s%.3fµs", sign, float64(nanos)/1000.0)
 	default:
-		return fmt.Sprintf("%dns", nanos)
+		return fmt.Sprintf("%s%dns", sign, nanos)
 	}
 }
 
-// String returns a human-readable representation of the duration
+// String returns a human-readable representation of the duration. Negative
+// durations start with a minus sign that applies to every component, and a
+// component whose sign differs from the rest is written with its own sign,
+// so {Months: 1, Days: -1} is "1mo -1d" and {Months: -1, Days: 1} is
+// "-1mo +1d".
+//
+// Sub-second amounts of a millisecond or more are rounded to the microsecond
+// ("1.235ms" for 1234567ns), so String does not always round-trip through
+// ParseDuration; use Canonical for an exact encoding.
 func (d Duration) String() string {
 	if d.IsZero() {
 		return "0s"
 	}
 
 	isNegative := d.isNegativeDuration()
-	temp := d
+	parts := d.formatMainUnits(isNegative)
+	if nanos := d.formatNanos(isNegative); nanos != "" {
+		parts = append(parts, nanos)
+	}
+
+	result := strings.Join(parts, " ")
 	if isNegative {
-		temp = temp.abs()
+		return "-" + result
 	}
+	return result
+}
 
-	parts := temp.formatMainUnits()
-	if nanos := temp.formatNanos(); nanos != "" {
-		parts = append(parts, nanos)
+// Formatter is a Duration that implements fmt.Formatter. Duration cannot
+// implement it directly because its Format method renders layout strings, so
+// convert a value to use the extra verbs:
+//
+//	fmt.Printf("%+v", hdur.Formatter(d)) // "1 year 2 months 3 days"
+//
+// Supported verbs:
+// %v  - compact form, as returned by String
+// %+v - long form, as returned by Long
+// %#v - Go-syntax constructor expression, as returned by GoString
+// %s  - compact form, honoring width, precision and the '-' flag
+// %q  - quoted compact form
+// %d  - total nanoseconds, only for fixed-length values that fit in an int64
+type Formatter Duration
+
+// Format implements fmt.Formatter
+func (f Formatter) Format(s fmt.State, verb rune) {
+	d := Duration(f)
+
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('#'):
+			_, _ = io.WriteString(s, d.GoString())
+		case s.Flag('+'):
+			fmt.Fprintf(s, fmt.FormatString(s, 's'), d.Long())
+		default:
+			fmt.Fprintf(s, fmt.FormatString(s, 's'), d.String())
+		}
+	case 's', 'q':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), d.String())
+	case 'd':
+		nanos, ok := d.checkedFixedNanos()
+		if d.Years != 0 || d.Months != 0 || !ok {
+			fmt.Fprintf(s, "%%!d(hdur.Duration=%s)", d.String())
+			return
+		}
+		fmt.Fprintf(s, fmt.FormatString(s, verb), nanos)
+	default:
+		fmt.Fprintf(s, "%%!%c(hdur.Duration=%s)", verb, d.String())
+	}
+}
+
+// fixedNanos returns the total nanoseconds in the days through nanoseconds
+// components, counting a day as 24 hours
+func (d Duration) fixedNanos() int64 {
+	return int64(d.Days)*int64(24*time.Hour) +
+		int64(d.Hours)*int64(time.Hour) +
+		int64(d.Minutes)*int64(time.Minute) +
+		int64(d.Seconds)*int64(time.Second) +
+		int64(d.Nanos)
+}
+
+// GoString implements fmt.GoStringer and returns a Go-syntax constructor
+// expression for the duration, used by the %#v verb
+func (d Duration) GoString() string {
+	fields := []struct {
+		name  string
+		value int
+	}{
+		{"Years", d.Years},
+		{"Months", d.Months},
+		{"Days", d.Days},
+		{"Hours", d.Hours},
+		{"Minutes", d.Minutes},
+		{"Seconds", d.Seconds},
+		{"Nanos", d.Nanos},
+	}
+
+	parts := []string{}
+	for _, field := range fields {
+		if field.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", field.name, field.value))
+		}
+	}
+
+	return "hdur.Duration{" + strings.Join(parts, ", ") + "}"
+}
+
+// pluralize formats n followed by the singular or plural form of unit
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// Long returns the duration with fully spelled out units, such as
+// "1 year 2 months 3 days"
+func (d Duration) Long() string {
+	if d.IsZero() {
+		return "0 seconds"
+	}
+
+	isNegative := d.isNegativeDuration()
+	units := []struct {
+		value int
+		name  string
+	}{
+		{d.Years, "year"},
+		{d.Months, "month"},
+		{d.Days, "day"},
+		{d.Hours, "hour"},
+		{d.Minutes, "minute"},
+		{d.Seconds, "second"},
+	}
+
+	parts := []string{}
+	for _, unit := range units {
+		if unit.value != 0 {
+			parts = append(parts, componentSign(unit.value, isNegative)+pluralize(abs(unit.value), unit.name))
+		}
+	}
+
+	switch sign, nanos := componentSign(d.Nanos, isNegative), abs(d.Nanos); {
+	case nanos == 0:
+	case nanos%1000000 == 0:
+		parts = append(parts, sign+pluralize(nanos/1000000, "millisecond"))
+	case nanos%1000 == 0:
+		parts = append(parts, sign+pluralize(nanos/1000, "microsecond"))
+	default:
+		parts = append(parts, sign+pluralize(nanos, "nanosecond"))
 	}
 
 	result := strings.Join(parts, " ")
@@ -119,19 +275,123 @@ func (d Duration) String() string {
 	return result
 }
 
-// MarshalJSON implements json.Marshaler
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + d.String() + `"`), nil
+// canonicalUnits lists the units written by Canonical, largest first
+var canonicalUnits = []string{"y", "mo", "d", "h", "m", "s"}
+
+// canonicalComponent formats a single component for Canonical and String,
+// writing its sign only when it differs from the leading sign of the duration
+func canonicalComponent(n int, unit string, negative bool) string {
+	magnitude := uint64(n)
+	if n < 0 {
+		magnitude = uint64(-int64(n))
+	}
+	return componentSign(n, negative) + strconv.FormatUint(magnitude, 10) + unit
 }
 
-// UnmarshalJSON implements json.Unmarshaler
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
+// Canonical returns the canonical text encoding of the duration. Unlike
+// String it normalizes the duration first and never rounds sub-millisecond
+// remainders, so ParseDuration(d.Canonical()) always returns a Duration equal
+// to d. This is the form written by MarshalJSON and Value.
+func (d Duration) Canonical() string {
+	d.normalize()
+	if d.IsZero() {
+		return "0s"
+	}
+
+	negative := d.isNegativeDuration()
+	values := []int{d.Years, d.Months, d.Days, d.Hours, d.Minutes, d.Seconds}
+
+	parts := []string{}
+	for i, value := range values {
+		if value != 0 {
+			parts = append(parts, canonicalComponent(value, canonicalUnits[i], negative))
+		}
+	}
+
+	if nanos := d.Nanos; nanos != 0 {
+		switch {
+		case nanos%1000000 == 0:
+			parts = append(parts, canonicalComponent(nanos/1000000, "ms", negative))
+		case nanos%1000 == 0:
+			parts = append(parts, canonicalComponent(nanos/1000, "us", negative))
+		default:
+			parts = append(parts, canonicalComponent(nanos, "ns", negative))
+		}
+	}
+
+	if negative {
+		return "-" + strings.Join(parts, " ")
+	}
+	return strings.Join(parts, " ")
+}
+
+// isoComponent formats a single ISO 8601 component, omitting it when zero
+func isoComponent(n int, designator string) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n) + designator
+}
+
+// ISO8601 returns the duration in ISO 8601 format, such as "P1Y2M3DT4H5M6.5S".
+// Negative durations are prefixed with a minus sign ("-P1D"), which is also the
+// lexical form of xs:duration. The leading sign applies to every component,
+// so a component whose sign differs from the rest of the duration is written
+// with a minus sign of its own: {Months: -1, Days: 1} is "-P1M-1D". ISO 8601-2
+// permits this, but some parsers may reject it.
+func (d Duration) ISO8601() string {
+	d.normalize()
+	if d.IsZero() {
+		return "PT0S"
 	}
 
-	parsed, err := ParseDuration(s)
+	isNegative := d.isNegativeDuration()
+	if isNegative {
+		d.negate()
+	}
+
+	date := isoComponent(d.Years, "Y") + isoComponent(d.Months, "M") + isoComponent(d.Days, "D")
+	clock := isoComponent(d.Hours, "H") + isoComponent(d.Minutes, "M")
+
+	// Seconds and nanoseconds share the S designator, so combine them before
+	// formatting in case their signs differ
+	if nanos := int64(d.Seconds)*1000000000 + int64(d.Nanos); nanos != 0 {
+		sign := ""
+		if nanos < 0 {
+			sign = "-"
+			nanos = -nanos
+		}
+		seconds := strconv.FormatInt(nanos/1000000000, 10)
+		if frac := nanos % 1000000000; frac != 0 {
+			seconds += "." + strings.TrimRight(fmt.Sprintf("%09d", frac), "0")
+		}
+		clock += sign + seconds + "S"
+	}
+
+	result := "P" + date
+	if clock != "" {
+		result += "T" + clock
+	}
+	if isNegative {
+		return "-" + result
+	}
+	return result
+}
+
+// AppendText implements encoding.TextAppender, appending the canonical
+// encoding of the duration to b
+func (d Duration) AppendText(b []byte) ([]byte, error) {
+	return append(b, d.Canonical()...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return d.AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(b []byte) error {
+	parsed, err := ParseDuration(string(b))
 	if err != nil {
 		return err
 	}
@@ -140,12 +400,45 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides the string form it
+// accepts integer nanoseconds (30000000000) and component objects
+// ({"months": 1, "days": 3}), as written by NanosDuration and ObjectDuration.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 {
+		switch c := b[0]; {
+		case c == '{':
+			return d.unmarshalJSONObject(b)
+		case c == '-' || (c >= '0' && c <= '9'):
+			return d.unmarshalJSONNanos(b)
+		}
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
+
 // Value implements the driver.Valuer interface
 func (d Duration) Value() (driver.Value, error) {
-	return d.String(), nil
+	return d.Canonical(), nil
 }
 
-// Scan implements the sql.Scanner interface
+// Scan implements the sql.Scanner interface. Text values are parsed with
+// ParseDuration, while int64 and integral float64 values are read as
+// nanoseconds, as written by NanosDuration.
 func (d *Duration) Scan(value interface{}) error {
 	var err error
 
@@ -154,6 +447,10 @@ func (d *Duration) Scan(value interface{}) error {
 		*d, err = ParseDuration(string(v))
 	case string:
 		*d, err = ParseDuration(v)
+	case int64, float64:
+		var nanos int64
+		nanos, err = scanInt64(v)
+		*d = ParseDurationFromNanos(nanos)
 	case nil:
 		*d = Duration{}
 	default:
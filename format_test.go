@@ -2,6 +2,8 @@ package hdur
 
 import (
 	"encoding/json"
+	"flag"
+	"fmt"
 	"testing"
 )
 
@@ -155,6 +157,16 @@ func TestDuration_String_Edge_Cases(t *testing.T) {
 			d:    Duration{Nanos: -500},
 			want: "-500ns",
 		},
+		{
+			name: "mixed signs",
+			d:    Duration{Months: 1, Days: -1},
+			want: "1mo -1d",
+		},
+		{
+			name: "negative mixed signs",
+			d:    Duration{Months: -1, Days: 1, Hours: -2, Nanos: 1500000},
+			want: "-1mo +1d 2h +1.500ms",
+		},
 	}
 
 	for _, tt := range tests {
@@ -234,6 +246,18 @@ func TestDurationJSON(t *testing.T) {
 			expected: `{"duration":"0s"}`,
 			input:    `{"duration":"0s"}`,
 		},
+		{
+			name:     "sub-millisecond duration",
+			d:        Duration{Seconds: 1, Nanos: 1500000},
+			expected: `{"duration":"1s 1500us"}`,
+			input:    `{"duration":"1s 1500us"}`,
+		},
+		{
+			name:     "negative duration",
+			d:        Duration{Hours: -2, Nanos: -7},
+			expected: `{"duration":"-2h 7ns"}`,
+			input:    `{"duration":"-2h 7ns"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -360,10 +384,40 @@ func TestDuration_UnmarshalJSON_Edge_Cases(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "number instead of string",
-			json:    `123`,
+			name: "integer nanoseconds",
+			json: `123`,
+			want: Duration{Nanos: 123},
+		},
+		{
+			name: "large integer nanoseconds",
+			json: `30000000000`,
+			want: Duration{Seconds: 30},
+		},
+		{
+			name: "negative integer nanoseconds",
+			json: `-1500000000`,
+			want: Duration{Seconds: -1, Nanos: -500000000},
+		},
+		{
+			name:    "fractional nanoseconds",
+			json:    `1.5`,
+			wantErr: true,
+		},
+		{
+			name: "component object",
+			json: `{"months": 1, "days": 3}`,
+			want: Duration{Months: 1, Days: 3},
+		},
+		{
+			name:    "component object with unknown key",
+			json:    `{"fortnights": 1}`,
 			wantErr: true,
 		},
+		{
+			name: "ISO 8601 string",
+			json: `"P1DT12H"`,
+			want: Duration{Days: 1, Hours: 12},
+		},
 	}
 
 	for _, tt := range tests {
@@ -380,3 +434,309 @@ func TestDuration_UnmarshalJSON_Edge_Cases(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_Long(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		want string
+	}{
+		{"zero", Duration{}, "0 seconds"},
+		{"singular units", Duration{Years: 1, Months: 1, Days: 1}, "1 year 1 month 1 day"},
+		{"plural units", Duration{Hours: 2, Minutes: 30, Seconds: 5}, "2 hours 30 minutes 5 seconds"},
+		{"milliseconds", Duration{Seconds: 1, Nanos: 500000000}, "1 second 500 milliseconds"},
+		{"microseconds", Duration{Nanos: 3000}, "3 microseconds"},
+		{"nanoseconds", Duration{Nanos: 3500}, "3500 nanoseconds"},
+		{"negative", Duration{Days: -3, Hours: -4}, "-3 days 4 hours"},
+		{"mixed signs", Duration{Months: 1, Days: -1}, "1 month -1 day"},
+		{"negative mixed signs", Duration{Months: -1, Days: 1, Nanos: -3000}, "-1 month +1 day 3 microseconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Long(); got != tt.want {
+				t.Errorf("Long() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	d := Duration{Years: 1, Months: 2, Days: 3}
+	fixed := Duration{Hours: 1, Minutes: 30}
+
+	tests := []struct {
+		name   string
+		format string
+		arg    interface{}
+		want   string
+	}{
+		{"compact", "%v", Formatter(d), "1y 2mo 3d"},
+		{"long", "%+v", Formatter(d), "1 year 2 months 3 days"},
+		{"go syntax", "%#v", Formatter(d), "hdur.Duration{Years: 1, Months: 2, Days: 3}"},
+		{"go syntax on Duration", "%#v", d, "hdur.Duration{Years: 1, Months: 2, Days: 3}"},
+		{"go syntax zero", "%#v", Duration{}, "hdur.Duration{}"},
+		{"quoted", "%q", Formatter(fixed), `"1h 30m"`},
+		{"string", "%s", Formatter(fixed), "1h 30m"},
+		{"right aligned", "%10s", Formatter(fixed), "    1h 30m"},
+		{"left aligned", "%-10s|", Formatter(fixed), "1h 30m    |"},
+		{"padded compact", "%8v", Formatter(fixed), "  1h 30m"},
+		{"nanoseconds", "%d", Formatter(fixed), "5400000000000"},
+		{"nanoseconds with days", "%d", Formatter(Duration{Days: 1}), "86400000000000"},
+		{"nanoseconds negative", "%d", Formatter(Duration{Seconds: -2}), "-2000000000"},
+		{"nanoseconds calendar", "%d", Formatter(d), "%!d(hdur.Duration=1y 2mo 3d)"},
+		{"nanoseconds overflow", "%d", Formatter(Duration{Days: 200000}), "%!d(hdur.Duration=200000d)"},
+		{"unsupported verb", "%x", Formatter(fixed), "%!x(hdur.Duration=1h 30m)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.arg); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Canonical(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		want string
+	}{
+		{"zero", Duration{}, "0s"},
+		{"full", Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, "1y 2mo 3d 4h 5m 6s"},
+		{"milliseconds", Duration{Nanos: 1500000}, "1500us"},
+		{"microseconds", Duration{Nanos: 2345}, "2345ns"},
+		{"exact milliseconds", Duration{Nanos: 3000000}, "3ms"},
+		{"negative", Duration{Days: -3, Hours: -4, Nanos: -7}, "-3d 4h 7ns"},
+		{"balanced signs", Duration{Hours: 1, Minutes: -30}, "30m"},
+		{"mixed signs", Duration{Months: 1, Days: -1}, "1mo -1d"},
+		{"negative mixed signs", Duration{Months: -1, Days: 1, Hours: -2}, "-1mo +1d 2h"},
+		{"normalized", Duration{Minutes: 90}, "1h 30m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Canonical(); got != tt.want {
+				t.Errorf("Canonical() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_String_RoundTrip(t *testing.T) {
+	// String rounds to three decimals, so only values it renders exactly
+	// are expected to survive a round trip
+	durations := []Duration{
+		{Nanos: 1500000},
+		{Nanos: 2345},
+		{Seconds: 1, Nanos: 3000},
+		{Years: -1, Months: -2, Days: -3},
+		MustParseDuration("1y 2mo 3d 4h 5m 6s"),
+		Months(1).Minus(Days(1)),
+		Months(-1).Plus(Days(1)),
+		{Years: -1, Days: 2, Hours: -3, Nanos: -4000},
+		{Days: 1, Hours: -2, Nanos: -1500000},
+	}
+
+	for _, d := range durations {
+		t.Run(d.String(), func(t *testing.T) {
+			for _, s := range []string{d.String(), d.Long()} {
+				got, err := ParseDuration(s)
+				if err != nil {
+					t.Fatalf("ParseDuration(%q) error = %v", s, err)
+				}
+				if got != d {
+					t.Errorf("ParseDuration(%q) = %#v, want %#v", s, got, d)
+				}
+			}
+		})
+	}
+}
+
+func FuzzDuration_Canonical(f *testing.F) {
+	f.Add(int32(1), int32(2), int32(3), int32(4), int32(5), int32(6), int32(7))
+	f.Add(int32(0), int32(0), int32(0), int32(0), int32(0), int32(0), int32(1500000))
+	f.Add(int32(-1), int32(-2), int32(-3), int32(0), int32(0), int32(0), int32(-2345))
+	f.Add(int32(0), int32(0), int32(0), int32(1), int32(-30), int32(0), int32(0))
+
+	f.Fuzz(func(t *testing.T, years, months, days, hours, minutes, seconds, nanos int32) {
+		d := Duration{
+			Years:   int(years),
+			Months:  int(months),
+			Days:    int(days),
+			Hours:   int(hours),
+			Minutes: int(minutes),
+			Seconds: int(seconds),
+			Nanos:   int(nanos),
+		}
+		want := d
+		want.normalize()
+
+		s := d.Canonical()
+		got, err := ParseDuration(s)
+		if err != nil {
+			t.Fatalf("ParseDuration(%q) error = %v", s, err)
+		}
+		if got != want {
+			t.Errorf("ParseDuration(%q) = %#v, want %#v", s, got, want)
+		}
+
+		iso := d.ISO8601()
+		got, err = ParseISO8601(iso)
+		if err != nil {
+			t.Fatalf("ParseISO8601(%q) error = %v", iso, err)
+		}
+		// ISO 8601 shares one designator between seconds and nanoseconds,
+		// so only their combined value is preserved
+		gotNanos := int64(got.Seconds)*1000000000 + int64(got.Nanos)
+		wantNanos := int64(want.Seconds)*1000000000 + int64(want.Nanos)
+		got.Seconds, got.Nanos, want.Seconds, want.Nanos = 0, 0, 0, 0
+		if got != want || gotNanos != wantNanos {
+			t.Errorf("ParseISO8601(%q) = %#v, want %#v", iso, got, want)
+		}
+	})
+}
+
+func TestDuration_ISO8601(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		want string
+	}{
+		{"zero", Duration{}, "PT0S"},
+		{"date only", Duration{Years: 1, Months: 2, Days: 3}, "P1Y2M3D"},
+		{"time only", Duration{Hours: 4, Minutes: 5, Seconds: 6}, "PT4H5M6S"},
+		{"full", Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, "P1Y2M3DT4H5M6S"},
+		{"fractional seconds", Duration{Seconds: 1, Nanos: 500000000}, "PT1.5S"},
+		{"nanoseconds only", Duration{Nanos: 7}, "PT0.000000007S"},
+		{"negative", Duration{Days: -1, Hours: -12}, "-P1DT12H"},
+		{"balanced signs", Duration{Hours: 1, Minutes: -30}, "PT30M"},
+		{"mixed signs", Duration{Months: 1, Days: -1}, "P1M-1D"},
+		{"negative mixed signs", Duration{Months: -1, Days: 1}, "-P1M-1D"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.ISO8601(); got != tt.want {
+				t.Errorf("ISO8601() = %q, want %q", got, tt.want)
+			}
+
+			want := tt.d
+			want.normalize()
+			if got, err := ParseISO8601(tt.want); err != nil || got != want {
+				t.Errorf("ParseISO8601(%q) = %#v, %v, want %#v", tt.want, got, err, want)
+			}
+		})
+	}
+}
+
+func TestDurationText(t *testing.T) {
+	d := Duration{Days: 1, Hours: 2, Nanos: 1500}
+
+	text, err := d.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error = %v", err)
+	}
+	if string(text) != "1d 2h 1500ns" {
+		t.Errorf("MarshalText() = %q, want %q", text, "1d 2h 1500ns")
+	}
+
+	appended, err := d.AppendText([]byte("ttl="))
+	if err != nil {
+		t.Fatalf("AppendText() error = %v", err)
+	}
+	if string(appended) != "ttl=1d 2h 1500ns" {
+		t.Errorf("AppendText() = %q, want %q", appended, "ttl=1d 2h 1500ns")
+	}
+
+	var got Duration
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+	if got != d {
+		t.Errorf("UnmarshalText() = %#v, want %#v", got, d)
+	}
+
+	if err := got.UnmarshalText([]byte("invalid")); err == nil {
+		t.Error("UnmarshalText() expected error for invalid input")
+	}
+}
+
+func TestDurationText_Integrations(t *testing.T) {
+	t.Run("json map key", func(t *testing.T) {
+		in := map[Duration]string{Hours(1): "hourly", Days(1): "daily"}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if string(data) != `{"1d":"daily","1h":"hourly"}` {
+			t.Errorf("Marshal() = %s", data)
+		}
+
+		var out map[Duration]string
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if out[Hours(1)] != "hourly" || out[Days(1)] != "daily" {
+			t.Errorf("Unmarshal() = %v", out)
+		}
+	})
+
+	t.Run("flag.TextVar", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		var d Duration
+		fs.TextVar(&d, "retention", Months(1), "retention period")
+		if err := fs.Parse([]string{"-retention", "1 year 6 months"}); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if d != (Duration{Years: 1, Months: 6}) {
+			t.Errorf("flag value = %v", d)
+		}
+	})
+}
+
+func FuzzDuration_String(f *testing.F) {
+	f.Add(int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7))
+	f.Add(int64(0), int64(1), int64(-1), int64(0), int64(0), int64(0), int64(1234567))
+	f.Add(int64(-1), int64(0), int64(1), int64(-2), int64(0), int64(0), int64(1500000))
+	f.Add(int64(1)<<40, int64(0), int64(-1)<<40, int64(0), int64(0), int64(0), int64(-999))
+
+	f.Fuzz(func(t *testing.T, years, months, days, hours, minutes, seconds, nanos int64) {
+		// Stay clear of values whose normalization would overflow an int
+		const limit = 1 << 50
+		for _, n := range []int64{years, months, days, hours, minutes, seconds, nanos} {
+			if n > limit || n < -limit {
+				t.Skip()
+			}
+		}
+
+		d := Duration{
+			Years:   int(years),
+			Months:  int(months),
+			Days:    int(days),
+			Hours:   int(hours),
+			Minutes: int(minutes),
+			Seconds: int(seconds),
+			Nanos:   int(nanos),
+		}
+		d.normalize()
+
+		// String writes sub-second amounts of a millisecond or more to the
+		// microsecond, so only those values are expected to round-trip
+		if d.Nanos >= 1000000 || d.Nanos <= -1000000 {
+			d.Nanos -= d.Nanos % 1000
+		}
+
+		for _, s := range []string{d.String(), d.Long()} {
+			got, err := ParseDuration(s)
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) error = %v", s, err)
+			}
+			if got != d {
+				t.Errorf("ParseDuration(%q) = %#v, want %#v", s, got, d)
+			}
+		}
+	})
+}
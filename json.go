@@ -0,0 +1,107 @@
+package hdur
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// jsonObject is the component object form of a Duration
+type jsonObject struct {
+	Years   int `json:"years,omitempty"`
+	Months  int `json:"months,omitempty"`
+	Days    int `json:"days,omitempty"`
+	Hours   int `json:"hours,omitempty"`
+	Minutes int `json:"minutes,omitempty"`
+	Seconds int `json:"seconds,omitempty"`
+	Nanos   int `json:"nanos,omitempty"`
+}
+
+// unmarshalJSONObject decodes the component object form, rejecting unknown keys
+func (d *Duration) unmarshalJSONObject(b []byte) error {
+	var obj jsonObject
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&obj); err != nil {
+		return fmt.Errorf("invalid duration object: %w", err)
+	}
+
+	*d = Duration(obj)
+	return nil
+}
+
+// unmarshalJSONNanos decodes an integer number of nanoseconds
+func (d *Duration) unmarshalJSONNanos(b []byte) error {
+	nanos, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration nanoseconds: %s", b)
+	}
+
+	*d = ParseDurationFromNanos(nanos)
+	return nil
+}
+
+// NanosDuration is a Duration that marshals to JSON as an integer number of
+// nanoseconds, for services that exchange Go's time.Duration as a number:
+//
+//	type Config struct {
+//		Timeout hdur.NanosDuration `json:"timeout"` // "timeout": 30000000000
+//	}
+//
+// Days count as 24 hours. Durations with years or months have no fixed length
+// and fail to marshal, as do durations too long for an int64, with an error
+// wrapping ErrOverflow. Unmarshaling accepts every form Duration does.
+type NanosDuration struct {
+	Duration
+}
+
+// MarshalJSON implements json.Marshaler
+func (d NanosDuration) MarshalJSON() ([]byte, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return nil, fmt.Errorf("cannot marshal %s as nanoseconds: duration has calendar units", d.Duration)
+	}
+	nanos, ok := d.checkedFixedNanos()
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %s as nanoseconds: %w", d.Duration, ErrOverflow)
+	}
+	return strconv.AppendInt(nil, nanos, 10), nil
+}
+
+// ObjectDuration is a Duration that marshals to JSON as an object of its
+// non-zero components, such as {"months":1,"days":3}. Unmarshaling accepts
+// every form Duration does.
+type ObjectDuration struct {
+	Duration
+}
+
+// MarshalJSON implements json.Marshaler
+func (d ObjectDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonObject(d.Duration))
+}
+
+// ISODuration is a Duration that marshals to text, JSON and XML in ISO 8601
+// format (the xs:duration lexical form), such as "P1Y2M3DT4H". Unmarshaling
+// accepts every form Duration does.
+type ISODuration struct {
+	Duration
+}
+
+// AppendText implements encoding.TextAppender
+func (d ISODuration) AppendText(b []byte) ([]byte, error) {
+	return append(b, d.ISO8601()...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (d ISODuration) MarshalText() ([]byte, error) {
+	return d.AppendText(nil)
+}
+
+// MarshalJSON implements json.Marshaler
+func (d ISODuration) MarshalJSON() ([]byte, error) {
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
@@ -0,0 +1,93 @@
+package hdur
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDurationJSON_Wrappers(t *testing.T) {
+	type config struct {
+		Timeout NanosDuration  `json:"timeout"`
+		Period  ObjectDuration `json:"period"`
+		TTL     ISODuration    `json:"ttl"`
+		Native  Duration       `json:"native"`
+	}
+
+	in := config{
+		Timeout: NanosDuration{Seconds(30)},
+		Period:  ObjectDuration{Duration{Months: 1, Days: 3}},
+		TTL:     ISODuration{Duration{Days: 1, Hours: 12}},
+		Native:  Hours(2),
+	}
+	want := `{"timeout":30000000000,"period":{"months":1,"days":3},"ttl":"P1DT12H","native":"2h"}`
+
+	got, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	var out config
+	if err := json.Unmarshal(got, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if out != in {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestDurationJSON_WrappersAcceptAllForms(t *testing.T) {
+	inputs := []string{`"1d 12h"`, `"P1DT12H"`, `129600000000000`, `{"days":1,"hours":12}`}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			var nanos NanosDuration
+			var obj ObjectDuration
+			var iso ISODuration
+			for _, v := range []interface{}{&nanos, &obj, &iso} {
+				if err := json.Unmarshal([]byte(input), v); err != nil {
+					t.Fatalf("Unmarshal(%T) error = %v", v, err)
+				}
+			}
+
+			want := Duration{Days: 1, Hours: 12}
+			if nanos.Duration != want || obj.Duration != want || iso.Duration != want {
+				t.Errorf("Unmarshal() = %v, %v, %v, want %v", nanos, obj, iso, want)
+			}
+		})
+	}
+}
+
+func TestNanosDuration_CalendarUnits(t *testing.T) {
+	if _, err := json.Marshal(NanosDuration{Months(1)}); err == nil {
+		t.Error("Marshal() expected error for calendar units")
+	}
+
+	got, err := json.Marshal(NanosDuration{Days(1)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != "86400000000000" {
+		t.Errorf("Marshal() = %s, want 86400000000000", got)
+	}
+}
+
+func TestNanosDuration_Overflow(t *testing.T) {
+	_, err := json.Marshal(NanosDuration{Duration{Days: 200000}})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("Marshal() error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestObjectDuration_Zero(t *testing.T) {
+	got, err := json.Marshal(ObjectDuration{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != "{}" {
+		t.Errorf("Marshal() = %s, want {}", got)
+	}
+}
@@ -20,26 +20,47 @@ func (d Duration) Equal(other Duration) bool {
 		d.Nanos == other.Nanos
 }
 
-// Less returns true if d is less than other
+// Compare returns -1 if d is shorter than other, 0 if they are the same
+// length and +1 if d is longer, suitable for slices.SortFunc. Years and
+// months are measured using DefaultConvention, so the result does not depend
+// on the current date: by default one month is 30.436875 days and one year
+// is 365.2425 days. Use CompareAt to measure from a specific instant.
+func (d Duration) Compare(other Duration) int {
+	return DefaultConvention.Compare(d, other)
+}
+
+// CompareAt is like Compare but measures both durations from ref
+func (d Duration) CompareAt(other Duration, ref time.Time) int {
+	return d.Add(ref).Compare(other.Add(ref))
+}
+
+// EquivalentAt reports whether d and other are the same length when measured
+// from ref. Unlike Equal, which compares components, it treats "30 days" and
+// "1 month" as equivalent from any date in April.
+func (d Duration) EquivalentAt(other Duration, ref time.Time) bool {
+	return d.CompareAt(other, ref) == 0
+}
+
+// Less returns true if d is less than other, as defined by Compare
 func (d Duration) Less(other Duration) bool {
-	// Compare using a fixed point in time
-	t := time.Now()
-	return d.Add(t).Before(other.Add(t))
+	return d.Compare(other) < 0
 }
 
-// LessOrEqual returns true if d is less than or equal to other
+// LessOrEqual returns true if d is less than or equal to other, as defined
+// by Compare
 func (d Duration) LessOrEqual(other Duration) bool {
-	return d.Less(other) || d.Equal(other)
+	return d.Compare(other) <= 0
 }
 
-// Greater returns true if d is greater than other
+// Greater returns true if d is greater than other, as defined by Compare
 func (d Duration) Greater(other Duration) bool {
-	return !d.LessOrEqual(other)
+	return d.Compare(other) > 0
 }
 
-// GreaterOrEqual returns true if d is greater than or equal to other
+// GreaterOrEqual returns true if d is greater than or equal to other, as
+// defined by Compare
 func (d Duration) GreaterOrEqual(other Duration) bool {
-	return !d.Less(other)
+	return d.Compare(other) >= 0
 }
 
 // Abs returns the absolute value of the duration
@@ -50,96 +71,163 @@ func (d Duration) Abs() Duration {
 	return d
 }
 
-// Mul returns the duration multiplied by the given factor
-func (d Duration) Mul(factor float64) Duration {
-	if factor == 0 {
-		return Duration{}
+// Plus returns the sum of d and other. Components are added field by field
+// and the result normalized, so years and months balance against each other,
+// as do hours through nanoseconds. Days are kept separate from months and
+// hours because their relation depends on the date the duration is applied
+// to: Months(1).Minus(Days(1)) is "1mo -1d", which Add applies as one month
+// forward and then one day back.
+func (d Duration) Plus(other Duration) Duration {
+	result := Duration{
+		Years:   d.Years + other.Years,
+		Months:  d.Months + other.Months,
+		Days:    d.Days + other.Days,
+		Hours:   d.Hours + other.Hours,
+		Minutes: d.Minutes + other.Minutes,
+		Seconds: d.Seconds + other.Seconds,
+		Nanos:   d.Nanos + other.Nanos,
 	}
+	result.normalize()
+	return result
+}
+
+// Minus returns d minus other, following the same rules as Plus
+func (d Duration) Minus(other Duration) Duration {
+	return d.Plus(other.Neg())
+}
 
-	// For negative factors, multiply by the absolute value and then negate
-	if factor < 0 {
-		factor = -factor
-		result := Duration{
-			Years:   -int(float64(d.Years) * factor),
-			Months:  -int(float64(d.Months) * factor),
-			Days:    -int(float64(d.Days) * factor),
-			Hours:   -int(float64(d.Hours) * factor),
-			Minutes: -int(float64(d.Minutes) * factor),
-			Seconds: -int(float64(d.Seconds) * factor),
-			Nanos:   -int(float64(d.Nanos) * factor),
+// Neg returns the duration with the sign of every component flipped
+func (d Duration) Neg() Duration {
+	d.negate()
+	return d
+}
+
+// Sum returns the total of the given durations, following the same rules as
+// Plus. The sum of no durations is zero.
+func Sum(durations ...Duration) Duration {
+	result := Duration{}
+	for _, d := range durations {
+		result = result.Plus(d)
+	}
+	return result
+}
+
+// monthLengthAt returns the number of days in the month that follows the
+// first months whole months from ref, in the direction of sign
+func monthLengthAt(ref time.Time, months int, sign int) float64 {
+	start := Duration{Months: months}.Add(ref)
+	end := Duration{Months: months + sign}.Add(ref)
+	return math.Abs(float64(end.Sub(start)) / float64(24*time.Hour))
+}
+
+// scale applies op to each group of components, carrying the fractional part
+// of months into days and of days into hours through nanoseconds. Fractional
+// months are measured from ref when it is non-nil, and otherwise use
+// DefaultConvention.
+func (d Duration) scale(op func(float64) float64, ref *time.Time) Duration {
+	months := op(float64(d.Years*12 + d.Months))
+	wholeMonths := math.Trunc(months)
+
+	monthDays := DefaultConvention.DaysPerMonth
+	if ref != nil && months != wholeMonths {
+		sign := 1
+		if months < 0 {
+			sign = -1
 		}
-		result.normalize()
-		return result
+		monthDays = monthLengthAt(*ref, int(wholeMonths), sign)
 	}
 
+	days := op(float64(d.Days)) + (months-wholeMonths)*monthDays
+	wholeDays := math.Trunc(days)
+
+	timeNanos := d.fixedNanos() - int64(d.Days)*int64(24*time.Hour)
+	nanos := math.Round(op(float64(timeNanos)) + (days-wholeDays)*float64(24*time.Hour))
+
 	result := Duration{
-		Years:   int(float64(d.Years) * factor),
-		Months:  int(float64(d.Months) * factor),
-		Days:    int(float64(d.Days) * factor),
-		Hours:   int(float64(d.Hours) * factor),
-		Minutes: int(float64(d.Minutes) * factor),
-		Seconds: int(float64(d.Seconds) * factor),
-		Nanos:   int(float64(d.Nanos) * factor),
+		Months: int(wholeMonths),
+		Days:   int(wholeDays),
+		Nanos:  int(nanos),
 	}
-
 	result.normalize()
 	return result
 }
 
-// Div returns the duration divided by the given divisor
+// Mul returns the duration multiplied by the given factor. Fractional parts
+// cascade into smaller units, so Days(1).Mul(1.5) is 1d 12h. A fractional
+// month carries into days using DefaultConvention, as Compare and ToStandard
+// measure it; use MulAt to measure it against a real month instead. The
+// result is rounded to the nearest nanosecond.
+func (d Duration) Mul(factor float64) Duration {
+	return d.scale(func(x float64) float64 { return x * factor }, nil)
+}
+
+// MulAt is like Mul but carries a fractional month into the number of days
+// in the calendar month it falls in, counted from ref. For example, half of
+// the month starting on 2024-02-01 is 14 days 12 hours.
+func (d Duration) MulAt(factor float64, ref time.Time) Duration {
+	return d.scale(func(x float64) float64 { return x * factor }, &ref)
+}
+
+// Div returns the duration divided by the given divisor, following the same
+// rules as Mul: Hours(1).Div(2) is 30m, and Months(1).Div(2) is half of a
+// DefaultConvention month, 15d 5h 14m 33s with the Gregorian convention.
 func (d Duration) Div(divisor float64) Duration {
 	if divisor == 0 {
 		panic("division by zero")
 	}
-	return d.Mul(1 / divisor)
+	return d.scale(func(x float64) float64 { return x / divisor }, nil)
 }
 
-// Round rounds the duration to the nearest multiple of the given duration
-func (d Duration) Round(multiple Duration) Duration {
-	if multiple.IsZero() {
-		return d
+// DivAt is like Div but carries a fractional month into the number of days
+// in the calendar month it falls in, counted from ref
+func (d Duration) DivAt(divisor float64, ref time.Time) Duration {
+	if divisor == 0 {
+		panic("division by zero")
 	}
+	return d.scale(func(x float64) float64 { return x / divisor }, &ref)
+}
 
-	// Convert to nanoseconds for comparison
-	t := time.Now()
-	dNanos := d.Add(t).Sub(t)
-	mNanos := multiple.Add(t).Sub(t)
-
-	if mNanos == 0 {
+// Round rounds the duration to the nearest multiple of the given duration,
+// with halfway values rounded away from zero. The result is expressed in
+// days and smaller units; use RoundTo to keep years and months. If either
+// duration does not fit in a time.Duration, d is returned unchanged, and a
+// result beyond that range is clamped to it.
+func (d Duration) Round(multiple Duration) Duration {
+	dNanos, mNanos, ok := d.standardPair(multiple)
+	if !ok {
 		return d
 	}
-
-	rounded := time.Duration(math.Round(float64(dNanos)/float64(mNanos))) * time.Duration(mNanos)
-	result := Duration{
-		Seconds: int(rounded.Seconds()),
-		Nanos:   int(rounded.Nanoseconds() % 1000000000),
-	}
-	result.normalize()
-	return result
+	return fixedDuration(int64(dNanos.Round(mNanos)))
 }
 
-// Truncate truncates the duration to a multiple of the given duration
+// Truncate truncates the duration toward zero to a multiple of the given
+// duration. The result is expressed in days and smaller units; use RoundTo
+// with RoundTrunc to keep years and months. If either duration does not fit
+// in a time.Duration, d is returned unchanged.
 func (d Duration) Truncate(multiple Duration) Duration {
-	if multiple.IsZero() {
+	dNanos, mNanos, ok := d.standardPair(multiple)
+	if !ok {
 		return d
 	}
+	return fixedDuration(int64(dNanos.Truncate(mNanos)))
+}
 
-	// Convert to nanoseconds for comparison
-	t := time.Now()
-	dNanos := d.Add(t).Sub(t)
-	mNanos := multiple.Add(t).Sub(t)
-
-	if mNanos == 0 {
-		return d
+// standardPair converts d and a non-zero multiple to time.Durations for
+// Round and Truncate, making the multiple positive. It reports false if
+// either does not fit or the multiple is zero.
+func (d Duration) standardPair(multiple Duration) (time.Duration, time.Duration, bool) {
+	dNanos, err := d.ToStandardChecked()
+	if err != nil {
+		return 0, 0, false
 	}
-
-	truncated := time.Duration(dNanos/mNanos) * time.Duration(mNanos)
-	result := Duration{
-		Seconds: int(truncated.Seconds()),
-		Nanos:   int(truncated.Nanoseconds() % 1000000000),
+	mNanos, err := multiple.ToStandardChecked()
+	if err != nil || mNanos == 0 || mNanos == math.MinInt64 {
+		return 0, 0, false
 	}
-	result.normalize()
-	return result
+	if mNanos < 0 {
+		mNanos = -mNanos
+	}
+	return dNanos, mNanos, true
 }
 
 func abs(x int) int {
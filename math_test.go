@@ -1,6 +1,7 @@
 package hdur
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -55,9 +56,9 @@ func TestDurationComparison(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "complex durations",
-			d1:       Days(30),
-			d2:       Months(1),
+			name:     "days and weeks",
+			d1:       Days(14),
+			d2:       Weeks(2),
 			expected: true,
 		},
 	}
@@ -259,6 +260,11 @@ func TestDuration_Abs(t *testing.T) {
 			d:    Duration{Years: -1, Months: -2, Days: -3},
 			want: Duration{Years: 1, Months: 2, Days: 3},
 		},
+		{
+			name: "long negative duration",
+			d:    Duration{Days: -200000},
+			want: Duration{Days: 200000},
+		},
 	}
 
 	for _, tt := range tests {
@@ -523,3 +529,238 @@ func TestDurationComparisons(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_Plus(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		o    Duration
+		want Duration
+	}{
+		{"simple", Hours(1), Minutes(30), Duration{Hours: 1, Minutes: 30}},
+		{"carry", Minutes(45), Minutes(30), Duration{Hours: 1, Minutes: 15}},
+		{"calendar", Months(8), Months(6), Duration{Years: 1, Months: 2}},
+		{"borrow time", Hours(1), Minutes(-30), Duration{Minutes: 30}},
+		{"borrow to negative", Minutes(30), Hours(-1), Duration{Minutes: -30}},
+		{"borrow months", Years(1), Months(-1), Duration{Months: 11}},
+		{"days are kept separate", Months(1), Days(-1), Duration{Months: 1, Days: -1}},
+		{"negative with positive days", Months(-1), Days(1), Duration{Months: -1, Days: 1}},
+		{"cancel out", Days(3), Days(-3), Duration{}},
+		{"nanoseconds", Duration{Seconds: 1}, Duration{Nanos: -1}, Duration{Nanos: 999999999}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Plus(tt.o); got != tt.want {
+				t.Errorf("Plus() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Minus(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Duration
+		o    Duration
+		want Duration
+	}{
+		{"simple", Hours(2), Minutes(30), Duration{Hours: 1, Minutes: 30}},
+		{"to negative", Minutes(30), Hours(1), Duration{Minutes: -30}},
+		{"self", MustParseDuration("1y 2mo 3d"), MustParseDuration("1y 2mo 3d"), Duration{}},
+		{"month minus day", Months(1), Days(1), Duration{Months: 1, Days: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Minus(tt.o); got != tt.want {
+				t.Errorf("Minus() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	// Applying the difference must match applying each duration in turn
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	diff := Months(1).Minus(Days(1))
+	if got, want := diff.Add(start), Days(-1).Add(Months(1).Add(start)); !got.Equal(want) {
+		t.Errorf("Minus().Add() = %v, want %v", got, want)
+	}
+}
+
+func TestDuration_Neg(t *testing.T) {
+	d := Duration{Years: 1, Months: -2, Days: 3, Nanos: -4}
+	want := Duration{Years: -1, Months: 2, Days: -3, Nanos: 4}
+	if got := d.Neg(); got != want {
+		t.Errorf("Neg() = %#v, want %#v", got, want)
+	}
+	if got := d.Neg().Neg(); got != d {
+		t.Errorf("Neg().Neg() = %#v, want %#v", got, d)
+	}
+}
+
+func TestSum(t *testing.T) {
+	if got := Sum(); got != (Duration{}) {
+		t.Errorf("Sum() = %#v, want zero", got)
+	}
+
+	got := Sum(Hours(20), Hours(5), Minutes(-30), Months(13))
+	want := Duration{Years: 1, Months: 1, Days: 1, Minutes: 30}
+	if got != want {
+		t.Errorf("Sum() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDuration_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		d1   Duration
+		d2   Duration
+		want int
+	}{
+		{"equal", Hours(24), Duration{Days: 1}, 0},
+		{"shorter", Hours(1), Hours(2), -1},
+		{"longer", Days(2), Hours(47), 1},
+		{"month longer than 30 days", Months(1), Duration{Days: 30}, 1},
+		{"month shorter than 31 days", Months(1), Duration{Days: 31}, -1},
+		{"year longer than 365 days", Years(1), Duration{Days: 365}, 1},
+		{"year shorter than 366 days", Years(1), Duration{Days: 366}, -1},
+		{"nanosecond next to a year", Duration{Years: 1, Nanos: 1}, Years(1), 1},
+		{"months balance into years", Months(13), Duration{Years: 1, Months: 1}, 0},
+		{"negative", Days(-1), Duration{}, -1},
+		{"opposite days near the limit", Duration{Days: 100000}, Duration{Days: -100000}, 1},
+		{"opposite hours near the limit", Duration{Hours: 2000000}, Duration{Hours: -2000000}, 1},
+		{"days beyond the limit", Duration{Days: 200000}, Duration{}, 1},
+		{"days and hours beyond the limit", Duration{Days: 200000, Hours: -1}, Duration{Days: 199999, Hours: 23, Nanos: 1}, -1},
+		{"months and opposite days", Duration{Months: 1, Days: 100000}, Duration{Days: -100000}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d1.Compare(tt.d2); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+			if got := tt.d2.Compare(tt.d1); got != -tt.want {
+				t.Errorf("reversed Compare() = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+
+	durations := []Duration{Months(1), Hours(1), {Days: 30}, Years(1), Minutes(-5)}
+	slices.SortFunc(durations, Duration.Compare)
+	want := []Duration{Minutes(-5), Hours(1), {Days: 30}, Months(1), Years(1)}
+	if !slices.Equal(durations, want) {
+		t.Errorf("SortFunc() = %v, want %v", durations, want)
+	}
+}
+
+func TestDuration_CompareAt(t *testing.T) {
+	month := Months(1)
+	days30 := Duration{Days: 30}
+
+	tests := []struct {
+		name string
+		ref  time.Time
+		want int
+	}{
+		{"february", time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), -1},
+		{"leap february", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), -1},
+		{"april", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"may", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := month.CompareAt(days30, tt.ref); got != tt.want {
+				t.Errorf("CompareAt() = %d, want %d", got, tt.want)
+			}
+			if got := month.EquivalentAt(days30, tt.ref); got != (tt.want == 0) {
+				t.Errorf("EquivalentAt() = %v, want %v", got, tt.want == 0)
+			}
+		})
+	}
+
+	if month.Equal(days30) {
+		t.Error("Equal() compares components, 1 month and 30 days should differ")
+	}
+}
+
+func TestDuration_MulDiv_Cascade(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Duration
+		want Duration
+	}{
+		{"days times one and a half", Days(1).Mul(1.5), Duration{Days: 1, Hours: 12}},
+		{"hour halved", Hours(1).Div(2), Duration{Minutes: 30}},
+		{"hour in thirds", Hours(1).Div(3), Duration{Minutes: 20}},
+		{"minute in sevenths", Minutes(1).Div(7), Duration{Seconds: 8, Nanos: 571428571}},
+		{"second halved", Seconds(1).Div(2), Duration{Nanos: 500000000}},
+		{"year halved", Years(1).Div(2), Duration{Months: 6}},
+		{"month halved", Months(1).Div(2), Duration{Days: 15, Hours: 5, Minutes: 14, Seconds: 33}},
+		{"month and a quarter", Months(1).Mul(1.25), Duration{Months: 1, Days: 7, Hours: 14, Minutes: 37, Seconds: 16, Nanos: 500000000}},
+		{"negative factor", Days(1).Mul(-1.5), Duration{Days: -1, Hours: -12}},
+		{"complex", MustParseDuration("1y 1mo 1d 1h").Div(2), Duration{Months: 6, Days: 15, Hours: 17, Minutes: 44, Seconds: 33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_MulDiv_Convention(t *testing.T) {
+	defer func(c Convention) { DefaultConvention = c }(DefaultConvention)
+
+	// Fractional months carry into days the same way Compare measures them
+	if got := Months(1).Div(2).Mul(2).Compare(Months(1)); got != 0 {
+		t.Errorf("Months(1).Div(2).Mul(2).Compare(Months(1)) = %d, want 0", got)
+	}
+	if got, want := Months(1).Div(2), DefaultConvention.Months(0.5); got != want {
+		t.Errorf("Months(1).Div(2) = %#v, want %#v", got, want)
+	}
+
+	DefaultConvention = Convention360
+	if got := Months(1).Div(2); got != (Duration{Days: 15}) {
+		t.Errorf("Months(1).Div(2) = %#v with 30-day months, want 15 days", got)
+	}
+	if got := Months(1.5); got != (Duration{Months: 1, Days: 15}) {
+		t.Errorf("Months(1.5) = %#v with 30-day months, want 1mo 15d", got)
+	}
+}
+
+func TestDuration_MulDivAt(t *testing.T) {
+	feb2024 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	feb2023 := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  Duration
+		want Duration
+	}{
+		{"leap february halved", Months(1).DivAt(2, feb2024), Duration{Days: 14, Hours: 12}},
+		{"february halved", Months(1).DivAt(2, feb2023), Duration{Days: 14}},
+		{"march halved", Months(1).DivAt(2, march), Duration{Days: 15, Hours: 12}},
+		{"fraction lands in later month", Months(1).MulAt(1.5, feb2024), Duration{Months: 1, Days: 15, Hours: 12}},
+		{"whole months ignore anchor", Months(2).DivAt(2, feb2024), Duration{Months: 1}},
+		{"negative fraction uses earlier month", Months(1).MulAt(-0.5, march), Duration{Days: -14, Hours: -12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on division by zero")
+		}
+	}()
+	Months(1).DivAt(0, march)
+}
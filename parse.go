@@ -2,12 +2,14 @@ package hdur
 
 import (
 	"fmt"
+	"math"
+	"math/bits"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
-var durationRegex = regexp.MustCompile(`(\d+)\s*([a-zA-Z]+)`)
+var durationRegex = regexp.MustCompile(`([+-]?)\s*(\d+)(?:\.(\d+))?\s*([a-zA-Zµμ]+)`)
 
 var unitMap = map[string]string{
 	"ns":           "nanos",
@@ -16,6 +18,8 @@ var unitMap = map[string]string{
 	"nanosecond":   "nanos",
 	"nanoseconds":  "nanos",
 	"us":           "micros",
+	"µs":           "micros",
+	"μs":           "micros",
 	"micro":        "micros",
 	"micros":       "micros",
 	"microsecond":  "micros",
@@ -67,12 +71,56 @@ func normalizeInput(s string) string {
 }
 
 // parseNumber extracts and validates the numeric part of a duration component
-func parseNumber(numStr string) (int64, error) {
-	n, err := strconv.ParseInt(numStr, 10, 64)
-	if err != nil {
+func parseNumber(numStr string, negative bool) (int64, error) {
+	n, err := strconv.ParseUint(numStr, 10, 64)
+	if err != nil || n > math.MaxInt64+1 || (!negative && n > math.MaxInt64) {
 		return 0, fmt.Errorf("invalid number: %s", numStr)
 	}
-	return n, nil
+	if negative {
+		return -int64(n), nil
+	}
+	return int64(n), nil
+}
+
+// unitNanos holds the length in nanoseconds of the units that accept
+// fractional amounts. Months and years have no fixed length.
+var unitNanos = map[string]uint64{
+	"nanos":      1,
+	"micros":     1000,
+	"millis":     1000000,
+	"seconds":    1000000000,
+	"minutes":    60 * 1000000000,
+	"hours":      3600 * 1000000000,
+	"days":       86400 * 1000000000,
+	"weeks":      7 * 86400 * 1000000000,
+	"fortnights": 14 * 86400 * 1000000000,
+}
+
+// parseFraction converts the digits after a decimal point into nanoseconds
+// of the given unit, truncating anything below one nanosecond
+func parseFraction(digits string, unit string) (int64, error) {
+	size, ok := unitNanos[unit]
+	if !ok {
+		return 0, fmt.Errorf("fractional %s are not supported", unit)
+	}
+
+	// Digits beyond 18 are below a nanosecond for every supported unit
+	if len(digits) > 18 {
+		digits = digits[:18]
+	}
+	frac, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", digits)
+	}
+
+	scale := uint64(1)
+	for range digits {
+		scale *= 10
+	}
+
+	hi, lo := bits.Mul64(frac, size)
+	nanos, _ := bits.Div64(hi, lo, scale)
+	return int64(nanos), nil
 }
 
 // normalizeUnit validates and normalizes the time unit
@@ -85,38 +133,131 @@ func normalizeUnit(unit string) (string, error) {
 	return normalized, nil
 }
 
-// applyUnit adds the specified duration to the Duration struct
-func applyUnit(d *Duration, n int64, unit string) {
+// applyUnit adds the specified duration to the Duration struct, returning an
+// error if the component it adds to would overflow
+func applyUnit(d *Duration, n int64, unit string) error {
+	var field *int
+	factor := int64(1)
 	switch unit {
 	case "nanos":
-		d.Nanos += int(n)
+		field = &d.Nanos
 	case "micros":
-		d.Nanos += int(n * 1000)
+		field, factor = &d.Nanos, 1000
 	case "millis":
-		d.Nanos += int(n * 1000000)
+		field, factor = &d.Nanos, 1000000
 	case "seconds":
-		d.Seconds += int(n)
+		field = &d.Seconds
 	case "minutes":
-		d.Minutes += int(n)
+		field = &d.Minutes
 	case "hours":
-		d.Hours += int(n)
+		field = &d.Hours
 	case "days":
-		d.Days += int(n)
+		field = &d.Days
 	case "weeks":
-		d.Days += int(n * 7)
+		field, factor = &d.Days, 7
 	case "fortnights":
-		d.Days += int(n * 14)
+		field, factor = &d.Days, 14
 	case "months":
-		d.Months += int(n)
+		field = &d.Months
 	case "years":
-		d.Years += int(n)
+		field = &d.Years
+	default:
+		return fmt.Errorf("unknown unit: %s", unit)
+	}
+
+	v, ok := mulInt64(n, factor)
+	if ok {
+		v, ok = addInt64(int64(*field), v)
 	}
+	if !ok {
+		return fmt.Errorf("%d %s is out of range", n, unit)
+	}
+	*field = int(v)
+	return nil
+}
+
+// isoAmount matches a single ISO 8601 component amount, allowing the
+// component signs written by Duration.ISO8601
+const isoAmount = `([+-]?\d+(?:[.,]\d+)?)`
+
+var iso8601Regex = regexp.MustCompile(`^([+-])?P(?:` + isoAmount + `Y)?(?:` + isoAmount + `M)?(?:` +
+	isoAmount + `W)?(?:` + isoAmount + `D)?(?:T(?:` + isoAmount + `H)?(?:` + isoAmount + `M)?(?:` +
+	isoAmount + `S)?)?$`)
+
+// iso8601Units maps the submatches of iso8601Regex to units
+var iso8601Units = []string{"years", "months", "weeks", "days", "hours", "minutes", "seconds"}
+
+// isISO8601 reports whether s looks like an ISO 8601 duration rather than a
+// human-readable one
+func isISO8601(s string) bool {
+	s = strings.TrimLeft(strings.TrimSpace(s), "+-")
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "p")
+}
+
+// ParseISO8601 parses an ISO 8601 duration such as "P1Y2M3DT4H5M6.5S" or
+// "-P1D", which is also the lexical form of xs:duration. Fractions are
+// accepted on weeks, days, hours, minutes and seconds, and components may
+// carry their own sign as written by Duration.ISO8601.
+func ParseISO8601(s string) (Duration, error) {
+	original := s
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	match := iso8601Regex.FindStringSubmatch(s)
+	if match == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return Duration{}, fmt.Errorf("invalid ISO 8601 duration: %s", original)
+	}
+
+	d := Duration{}
+	for i, amount := range match[2:] {
+		if amount == "" {
+			continue
+		}
+
+		negative := strings.HasPrefix(amount, "-") != (match[1] == "-")
+		amount = strings.TrimLeft(amount, "+-")
+		whole, fraction, _ := strings.Cut(strings.ReplaceAll(amount, ",", "."), ".")
+
+		n, err := parseNumber(whole, negative)
+		if err != nil {
+			return Duration{}, err
+		}
+		if err := applyUnit(&d, n, iso8601Units[i]); err != nil {
+			return Duration{}, err
+		}
+
+		if fraction != "" {
+			frac, err := parseFraction(fraction, iso8601Units[i])
+			if err != nil {
+				return Duration{}, err
+			}
+			if negative {
+				frac = -frac
+			}
+			if err := applyUnit(&d, frac, "nanos"); err != nil {
+				return Duration{}, err
+			}
+		}
+	}
+
+	d.normalize()
+	return d, nil
 }
 
 // ParseDuration parses a duration string and returns a Duration
 // It supports multiple time units and ignores conjunctions like "and"
 // Example: "1 day 3 hours and 5 minutes" or "2weeks 4days"
+//
+// Amounts of days and smaller units may have a decimal fraction ("1.5h").
+// A sign before the first component applies to every component without a
+// sign of its own, so "-1y 2mo" is the negation of "1y 2mo" while
+// "1h -30m" is thirty minutes. This makes the output of String and
+// Canonical readable by ParseDuration. Strings starting with "P" are parsed
+// as ISO 8601 durations by ParseISO8601.
 func ParseDuration(s string) (Duration, error) {
+	if isISO8601(s) {
+		return ParseISO8601(s)
+	}
+
 	s = normalizeInput(s)
 
 	matches := durationRegex.FindAllStringSubmatch(s, -1)
@@ -125,23 +266,47 @@ func ParseDuration(s string) (Duration, error) {
 	}
 
 	d := Duration{}
+	negative := false
 
-	for _, match := range matches {
-		if len(match) != 3 {
+	for i, match := range matches {
+		if len(match) != 5 {
 			continue
 		}
 
-		n, err := parseNumber(match[1])
+		// Unsigned components inherit the sign of the first component
+		sign := match[1]
+		if i == 0 {
+			negative = sign == "-"
+		} else if sign == "" && negative {
+			sign = "-"
+		}
+
+		n, err := parseNumber(match[2], sign == "-")
 		if err != nil {
 			return Duration{}, err
 		}
 
-		unit, err := normalizeUnit(match[2])
+		unit, err := normalizeUnit(match[4])
 		if err != nil {
 			return Duration{}, err
 		}
 
-		applyUnit(&d, n, unit)
+		if err := applyUnit(&d, n, unit); err != nil {
+			return Duration{}, err
+		}
+
+		if match[3] != "" {
+			frac, err := parseFraction(match[3], unit)
+			if err != nil {
+				return Duration{}, err
+			}
+			if sign == "-" {
+				frac = -frac
+			}
+			if err := applyUnit(&d, frac, "nanos"); err != nil {
+				return Duration{}, err
+			}
+		}
 	}
 
 	d.normalize()
@@ -83,6 +83,76 @@ func TestParseDuration(t *testing.T) {
 			input:    "500ms",
 			expected: Duration{Nanos: 500000000},
 		},
+		{
+			name:     "micro sign",
+			input:    "3µs",
+			expected: Duration{Nanos: 3000},
+		},
+		{
+			name:     "decimal milliseconds",
+			input:    "1.500ms",
+			expected: Duration{Nanos: 1500000},
+		},
+		{
+			name:     "decimal hours",
+			input:    "1.5h",
+			expected: Duration{Hours: 1, Minutes: 30},
+		},
+		{
+			name:     "decimal below a nanosecond",
+			input:    "0.0000000019s",
+			expected: Duration{Nanos: 1},
+		},
+		{
+			name:     "leading minus negates all components",
+			input:    "-1y 2mo 3d",
+			expected: Duration{Years: -1, Months: -2, Days: -3},
+		},
+		{
+			name:     "component signs",
+			input:    "1h -30m",
+			expected: Duration{Minutes: 30},
+		},
+		{
+			name:     "component signs across days",
+			input:    "1mo -1d",
+			expected: Duration{Months: 1, Days: -1},
+		},
+		{
+			name:     "explicit plus after leading minus",
+			input:    "-1mo +1d",
+			expected: Duration{Months: -1, Days: 1},
+		},
+		{
+			name:    "fractional months",
+			input:   "1.5mo",
+			wantErr: true,
+		},
+		{
+			name:    "number out of range",
+			input:   "9223372036854775808s",
+			wantErr: true,
+		},
+		{
+			name:    "microseconds out of range",
+			input:   "9223372036854775807us",
+			wantErr: true,
+		},
+		{
+			name:    "weeks out of range",
+			input:   "2000000000000000000w",
+			wantErr: true,
+		},
+		{
+			name:    "sum out of range",
+			input:   "9223372036854775807s 1s",
+			wantErr: true,
+		},
+		{
+			name:    "ISO 8601 weeks out of range",
+			input:   "P2000000000000000000W",
+			wantErr: true,
+		},
 		{
 			name:    "invalid unit",
 			input:   "5 invalid",
@@ -159,3 +229,40 @@ func TestParseDurationFromNanos(t *testing.T) {
 		})
 	}
 }
+
+func TestParseISO8601(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Duration
+		wantErr bool
+	}{
+		{name: "full", input: "P1Y2M3DT4H5M6S", want: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}},
+		{name: "weeks", input: "P2W", want: Duration{Days: 14}},
+		{name: "time only", input: "PT36H", want: Duration{Days: 1, Hours: 12}},
+		{name: "fractional seconds", input: "PT1.5S", want: Duration{Seconds: 1, Nanos: 500000000}},
+		{name: "comma fraction", input: "PT0,25H", want: Duration{Minutes: 15}},
+		{name: "negative", input: "-P1DT12H", want: Duration{Days: -1, Hours: -12}},
+		{name: "component sign", input: "PT1H-30M", want: Duration{Minutes: 30}},
+		{name: "component sign across days", input: "-P1M-1D", want: Duration{Months: -1, Days: 1}},
+		{name: "lowercase", input: "p1d", want: Duration{Days: 1}},
+		{name: "through ParseDuration", input: " P1M ", want: Duration{Months: 1}},
+		{name: "empty", input: "P", wantErr: true},
+		{name: "empty time", input: "P1DT", wantErr: true},
+		{name: "fractional months", input: "P1.5M", wantErr: true},
+		{name: "wrong order", input: "P1D1Y", wantErr: true},
+		{name: "missing designator", input: "P1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseDuration(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
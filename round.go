@@ -0,0 +1,260 @@
+package hdur
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Unit identifies a duration component for rounding and balancing
+type Unit int
+
+// Units from smallest to largest. The zero Unit is not a unit, so that
+// options can leave a unit unset.
+const (
+	UnitNanosecond Unit = iota + 1
+	UnitMicrosecond
+	UnitMillisecond
+	UnitSecond
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+)
+
+// unitNames holds the String form of each Unit
+var unitNames = []string{
+	"nanosecond", "microsecond", "millisecond", "second", "minute",
+	"hour", "day", "week", "month", "year",
+}
+
+// String returns the singular name of the unit
+func (u Unit) String() string {
+	if !u.valid() {
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+	return unitNames[u-1]
+}
+
+// valid reports whether u is one of the defined units
+func (u Unit) valid() bool {
+	return u >= UnitNanosecond && u <= UnitYear
+}
+
+// nanos returns the length of a fixed unit in nanoseconds, counting a day as
+// 24 hours. Months and years have no fixed length and return 0.
+func (u Unit) nanos() int64 {
+	switch u {
+	case UnitNanosecond:
+		return 1
+	case UnitMicrosecond:
+		return int64(time.Microsecond)
+	case UnitMillisecond:
+		return int64(time.Millisecond)
+	case UnitSecond:
+		return int64(time.Second)
+	case UnitMinute:
+		return int64(time.Minute)
+	case UnitHour:
+		return int64(time.Hour)
+	case UnitDay:
+		return int64(24 * time.Hour)
+	case UnitWeek:
+		return int64(7 * 24 * time.Hour)
+	default:
+		return 0
+	}
+}
+
+// RoundingMode selects how a value between two increments is rounded. The
+// zero value is unset and rounds toward zero like RoundTrunc, so options
+// that leave it out count whole units only.
+type RoundingMode int
+
+const (
+	// RoundHalfExpand rounds to the nearest increment, with ties away from zero
+	RoundHalfExpand RoundingMode = iota + 1
+	// RoundHalfEven rounds to the nearest increment, with ties to the even one
+	RoundHalfEven
+	// RoundTrunc rounds toward zero
+	RoundTrunc
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+	// RoundCeil rounds toward positive infinity
+	RoundCeil
+)
+
+// roundQuotient adjusts the truncated quotient q of a division whose
+// remainder, as a fraction of the divisor, is frac (-1 < frac < 1)
+func roundQuotient(q int64, frac float64, mode RoundingMode) int64 {
+	if frac == 0 {
+		return q
+	}
+
+	sign := int64(1)
+	if frac < 0 {
+		sign = -1
+	}
+	half := math.Abs(frac)
+
+	switch mode {
+	case RoundFloor:
+		if sign < 0 {
+			return q - 1
+		}
+	case RoundCeil:
+		if sign > 0 {
+			return q + 1
+		}
+	case RoundHalfExpand:
+		if half >= 0.5 {
+			return q + sign
+		}
+	case RoundHalfEven:
+		if half > 0.5 || (half == 0.5 && q%2 != 0) {
+			return q + sign
+		}
+	}
+	return q
+}
+
+// roundInt64 rounds n to a multiple of step using mode
+func roundInt64(n, step int64, mode RoundingMode) int64 {
+	q, r := n/step, n%step
+	var frac float64
+	switch {
+	case r == 0:
+	case 2*r == step:
+		frac = 0.5
+	case 2*r == -step:
+		frac = -0.5
+	default:
+		frac = float64(r) / float64(step)
+	}
+	return roundQuotient(q, frac, mode) * step
+}
+
+// calendarProgress returns how far target lies between start and end as a
+// fraction, where end is one unit after start in the direction of target
+func calendarProgress(start, end, target time.Time) float64 {
+	span := end.Sub(start)
+	if span == 0 {
+		return 0
+	}
+	return float64(target.Sub(start)) / float64(span)
+}
+
+// RoundTo rounds the duration to a multiple of increment units using mode,
+// keeping calendar components intact instead of converting through
+// nanoseconds:
+//
+//	d := hdur.MustParseDuration("1 year 2 months 20 days")
+//	d.RoundTo(hdur.UnitMonth, 1, hdur.RoundHalfExpand, ref) // 1y 3mo
+//
+// When rounding to days or smaller units the years and months are kept as
+// they are and days count as 24 hours. A duration too long to count in
+// nanoseconds is returned unchanged if the rounding step neither divides a
+// day nor is a whole number of days. When rounding to months or years, the
+// duration is applied to relativeTo and the whole calendar months or years it
+// reaches are counted, with the remainder measured as a fraction of the
+// actual calendar month or year it falls in, as InMonthsAt does. Days are
+// counted this way too, so 46 days from January 1 are more than one and a
+// half months. A zero relativeTo measures it using DefaultConvention instead.
+// RoundTo panics if increment is less than one or
+// unit is not a valid Unit.
+func (d Duration) RoundTo(unit Unit, increment int, mode RoundingMode, relativeTo time.Time) Duration {
+	if increment < 1 {
+		panic("invalid rounding increment")
+	}
+	if !unit.valid() {
+		panic("invalid rounding unit")
+	}
+	d.normalize()
+
+	if step := unit.nanos(); step != 0 {
+		result := Duration{Years: d.Years, Months: d.Months}
+		if fixed, ok := d.checkedFixedNanos(); ok {
+			return result.Plus(fixedDuration(roundInt64(fixed, step*int64(increment), mode)))
+		}
+
+		// Too long to count in nanoseconds, so round the days and the time
+		// of day separately
+		days, clock, ok := roundDays(int64(d.Days), d.clockNanos(), step*int64(increment), mode)
+		if !ok {
+			return d
+		}
+		result.Days = int(days)
+		return result.Plus(fixedDuration(clock))
+	}
+
+	unitMonths := 1
+	if unit == UnitYear {
+		unitMonths = 12
+	}
+
+	// Count the units the duration spans, measuring from relativeTo as
+	// InMonthsAt and InYearsAt do so that the remainder is less than a unit
+	var units float64
+	if relativeTo.IsZero() {
+		units = DefaultConvention.In(d, unit)
+	} else {
+		units = calendarUnitsAt(d.Add(relativeTo), relativeTo, unitMonths)
+	}
+
+	// Whole steps of increment units, with the remainder as a fraction of a
+	// step
+	steps := units / float64(increment)
+	q := math.Trunc(steps)
+	rounded := roundQuotient(int64(q), steps-q, mode)
+
+	result := Duration{Months: int(rounded) * increment * unitMonths}
+	result.normalize()
+	return result
+}
+
+// roundDays rounds the duration of days days and clock nanoseconds to a
+// multiple of step nanoseconds using mode, returning the rounded days and
+// nanoseconds. It reports false if step neither divides a day nor is a whole
+// number of days.
+func roundDays(days, clock, step int64, mode RoundingMode) (int64, int64, bool) {
+	day := int64(24 * time.Hour)
+
+	// Give the time of day the sign of the total so that it rounds in the
+	// same direction
+	switch {
+	case days > 0 && clock < 0:
+		days, clock = days-1, clock+day
+	case days < 0 && clock > 0:
+		days, clock = days+1, clock-day
+	}
+
+	switch {
+	case day%step == 0:
+		// Whole days are already a multiple of step
+		return days, roundInt64(clock, step, mode), true
+	case step%day == 0:
+		stepDays := step / day
+		q, r := days/stepDays, days%stepDays
+		frac := (float64(r)*dayNanos + float64(clock)) / float64(step)
+		return roundQuotient(q, frac, mode) * stepDays, 0, true
+	default:
+		return days, clock, false
+	}
+}
+
+// fixedDuration builds a normalized Duration from days through nanoseconds
+// totaling n nanoseconds
+func fixedDuration(n int64) Duration {
+	day := int64(24 * time.Hour)
+	d := Duration{Days: int(n / day)}
+	n %= day
+	d.Hours = int(n / int64(time.Hour))
+	n %= int64(time.Hour)
+	d.Minutes = int(n / int64(time.Minute))
+	n %= int64(time.Minute)
+	d.Seconds = int(n / int64(time.Second))
+	d.Nanos = int(n % int64(time.Second))
+	return d
+}
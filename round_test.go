@@ -0,0 +1,95 @@
+package hdur
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUnit_String(t *testing.T) {
+	if got := UnitMonth.String(); got != "month" {
+		t.Errorf("String() = %q, want month", got)
+	}
+	if got := Unit(42).String(); got != "Unit(42)" {
+		t.Errorf("String() = %q, want Unit(42)", got)
+	}
+}
+
+func TestDuration_RoundTo(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		d         Duration
+		unit      Unit
+		increment int
+		mode      RoundingMode
+		ref       time.Time
+		want      Duration
+	}{
+		{"month half expand up", MustParseDuration("1y 2mo 20d"), UnitMonth, 1, RoundHalfExpand, jan, Duration{Years: 1, Months: 3}},
+		{"month floor", MustParseDuration("1y 2mo 20d"), UnitMonth, 1, RoundFloor, jan, Duration{Years: 1, Months: 2}},
+		{"month ceil", MustParseDuration("1y 2mo 1h"), UnitMonth, 1, RoundCeil, jan, Duration{Years: 1, Months: 3}},
+		{"month trunc", MustParseDuration("2mo 29d"), UnitMonth, 1, RoundTrunc, jan, Duration{Months: 2}},
+		{"unset mode truncates", MustParseDuration("2mo 29d"), UnitMonth, 1, RoundingMode(0), jan, Duration{Months: 2}},
+		{"month half in short february", Duration{Days: 14, Hours: 12}, UnitMonth, 1, RoundHalfExpand, feb, Duration{Months: 1}},
+		{"month below half in long january", Duration{Days: 15}, UnitMonth, 1, RoundHalfExpand, jan, Duration{}},
+		{"month half even", Duration{Days: 14, Hours: 12}, UnitMonth, 1, RoundHalfEven, feb, Duration{}},
+		{"month increment", Months(4), UnitMonth, 3, RoundHalfExpand, jan, Duration{Months: 3}},
+		{"month increment ceil", Months(4), UnitMonth, 3, RoundCeil, jan, Duration{Months: 6}},
+		{"days beyond a month", Duration{Days: 45}, UnitMonth, 1, RoundHalfExpand, jan, Duration{Months: 1}},
+		{"days past half of the second month", Duration{Days: 46}, UnitMonth, 1, RoundHalfExpand, jan, Duration{Months: 2}},
+		{"days across several months", Duration{Days: 75}, UnitMonth, 1, RoundTrunc, jan, Duration{Months: 2}},
+		{"negative days beyond a month", Duration{Days: -46}, UnitMonth, 1, RoundHalfExpand, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Duration{Months: -2}},
+		{"days beyond a year", Duration{Days: 550}, UnitYear, 1, RoundHalfExpand, jan, Duration{Years: 2}},
+		{"negative month", Duration{Months: -1, Days: -20}, UnitMonth, 1, RoundHalfExpand, jan, Duration{Months: -2}},
+		{"negative month floor", Duration{Months: -1, Days: -1}, UnitMonth, 1, RoundFloor, jan, Duration{Months: -2}},
+		{"negative month ceil", Duration{Months: -1, Days: -1}, UnitMonth, 1, RoundCeil, jan, Duration{Months: -1}},
+		{"year", MustParseDuration("1y 7mo"), UnitYear, 1, RoundHalfExpand, jan, Duration{Years: 2}},
+		{"year trunc", MustParseDuration("1y 11mo 30d"), UnitYear, 1, RoundTrunc, jan, Duration{Years: 1}},
+		{"day keeps months", MustParseDuration("1y 2mo 3d 13h"), UnitDay, 1, RoundHalfExpand, jan, Duration{Years: 1, Months: 2, Days: 4}},
+		{"hour keeps calendar", MustParseDuration("1mo 2d 3h 30m"), UnitHour, 1, RoundHalfEven, jan, Duration{Months: 1, Days: 2, Hours: 4}},
+		{"hour half even down", MustParseDuration("2h 30m"), UnitHour, 1, RoundHalfEven, jan, Duration{Hours: 2}},
+		{"minutes increment", Duration{Minutes: 7, Seconds: 30}, UnitMinute, 5, RoundHalfExpand, jan, Duration{Minutes: 10}},
+		{"minutes floor negative", Duration{Minutes: -7}, UnitMinute, 5, RoundFloor, jan, Duration{Minutes: -10}},
+		{"minutes trunc negative", Duration{Minutes: -7}, UnitMinute, 5, RoundTrunc, jan, Duration{Minutes: -5}},
+		{"week", Duration{Days: 10}, UnitWeek, 1, RoundHalfExpand, jan, Duration{Days: 7}},
+		{"millisecond", Duration{Nanos: 1500000}, UnitMillisecond, 1, RoundHalfExpand, jan, Duration{Nanos: 2000000}},
+		{"long days", Duration{Days: 200000}, UnitDay, 1, RoundHalfExpand, time.Time{}, Duration{Days: 200000}},
+		{"long days half up", Duration{Days: 200000, Hours: 13}, UnitDay, 1, RoundHalfExpand, time.Time{}, Duration{Days: 200001}},
+		{"long days with negative hours", Duration{Days: 200000, Hours: -13}, UnitDay, 1, RoundTrunc, time.Time{}, Duration{Days: 199999}},
+		{"long negative days", Duration{Days: -200000, Hours: -13}, UnitDay, 1, RoundHalfExpand, time.Time{}, Duration{Days: -200001}},
+		{"long hours", Duration{Days: 200000, Minutes: 30}, UnitHour, 1, RoundHalfExpand, time.Time{}, Duration{Days: 200000, Hours: 1}},
+		{"long weeks", Duration{Days: 200003, Hours: 12}, UnitWeek, 1, RoundHalfExpand, time.Time{}, Duration{Days: 200004}},
+		{"long with uneven step", Duration{Days: 200000, Minutes: 3}, UnitMinute, 7, RoundHalfExpand, time.Time{}, Duration{Days: 200000, Minutes: 3}},
+		{"zero reference", Duration{Days: 15, Hours: 12}, UnitMonth, 1, RoundHalfExpand, time.Time{}, Duration{Months: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.d.RoundTo(tt.unit, tt.increment, tt.mode, tt.ref)
+			if got != tt.want {
+				t.Errorf("RoundTo() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for zero increment")
+		}
+	}()
+	Hours(1).RoundTo(UnitHour, 0, RoundTrunc, jan)
+}
+
+func TestDuration_RoundTo_MatchesInMonthsAt(t *testing.T) {
+	ref := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for days := -400; days <= 400; days++ {
+		d := Duration{Days: days}
+		want := Months(math.Round(d.InMonthsAt(ref)))
+		if got := d.RoundTo(UnitMonth, 1, RoundHalfExpand, ref); got != want {
+			t.Fatalf("%v.RoundTo(UnitMonth) = %v, want %v (%v months)", d, got, want, d.InMonthsAt(ref))
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package hdur
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// scanInt64 converts an integer driver value to an int64. Some drivers
+// return numeric columns as float64 or text, so those are accepted as long
+// as they hold a whole number.
+func scanInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("cannot scan non-integral value %v into Duration", v)
+		}
+		return int64(v), nil
+	case []byte:
+		return scanInt64(string(v))
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot scan %q as an integer", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("cannot scan type %T as an integer", value)
+	}
+}
+
+// NullDuration represents a Duration that may be null. It implements the
+// sql.Scanner interface so it can be used as a scan destination, similar to
+// sql.NullString. Valid values are stored as text; use *NanosDuration for a
+// nullable BIGINT column, since a nil pointer is written as NULL.
+type NullDuration struct {
+	Duration Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullDuration) Scan(value interface{}) error {
+	if value == nil {
+		n.Duration, n.Valid = Duration{}, false
+		return nil
+	}
+
+	if err := n.Duration.Scan(value); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Duration.Value()
+}
+
+// Value implements the driver.Valuer interface, storing the duration as a
+// BIGINT number of nanoseconds. Days count as 24 hours. Durations with years
+// or months cannot be stored, nor can durations too long for a BIGINT, which
+// return an error wrapping ErrOverflow.
+func (d NanosDuration) Value() (driver.Value, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return nil, fmt.Errorf("cannot store %s as nanoseconds: duration has calendar units", d.Duration)
+	}
+	nanos, ok := d.checkedFixedNanos()
+	if !ok {
+		return nil, fmt.Errorf("cannot store %s as nanoseconds: %w", d.Duration, ErrOverflow)
+	}
+	return nanos, nil
+}
+
+// Scan implements the sql.Scanner interface. In addition to every value
+// Duration accepts, it reads integers returned as text, which some drivers
+// use for BIGINT columns.
+func (d *NanosDuration) Scan(value interface{}) error {
+	switch value.(type) {
+	case []byte, string:
+		if nanos, err := scanInt64(value); err == nil {
+			d.Duration = ParseDurationFromNanos(nanos)
+			return nil
+		}
+	}
+	return d.Duration.Scan(value)
+}
+
+// DurationColumns holds a Duration split for storage in separate months,
+// days and nanoseconds columns, the same layout PostgreSQL uses for its
+// interval type. Years are folded into Months and hours through nanoseconds
+// into Nanos, so a round trip returns an equal, normalized Duration.
+//
+//	c := d.Columns()
+//	db.Exec(`INSERT INTO plans (months, days, nanos) VALUES (?, ?, ?)`, c.Args()...)
+//
+//	var c hdur.DurationColumns
+//	row.Scan(c.Dest()...)
+//	d := c.Duration()
+type DurationColumns struct {
+	Months int64
+	Days   int64
+	Nanos  int64
+}
+
+// Columns splits the duration into months, days and nanoseconds columns
+func (d Duration) Columns() DurationColumns {
+	return DurationColumns{
+		Months: int64(d.Years)*12 + int64(d.Months),
+		Days:   int64(d.Days),
+		Nanos: int64(d.Hours)*3600000000000 + int64(d.Minutes)*60000000000 +
+			int64(d.Seconds)*1000000000 + int64(d.Nanos),
+	}
+}
+
+// Duration combines the columns back into a normalized Duration
+func (c DurationColumns) Duration() Duration {
+	d := Duration{Months: int(c.Months), Days: int(c.Days)}
+
+	// Split nanoseconds before normalizing so that large values cannot
+	// overflow into days
+	d.Hours = int(c.Nanos / 3600000000000)
+	rest := c.Nanos % 3600000000000
+	d.Minutes = int(rest / 60000000000)
+	rest %= 60000000000
+	d.Seconds = int(rest / 1000000000)
+	d.Nanos = int(rest % 1000000000)
+
+	d.normalize()
+	return d
+}
+
+// Args returns the column values in months, days, nanoseconds order for use
+// as query arguments
+func (c DurationColumns) Args() []interface{} {
+	return []interface{}{c.Months, c.Days, c.Nanos}
+}
+
+// Dest returns scan destinations for the months, days and nanoseconds
+// columns, in that order. Each accepts int64, float64 and text driver values.
+func (c *DurationColumns) Dest() []interface{} {
+	return []interface{}{
+		&columnScanner{dst: &c.Months},
+		&columnScanner{dst: &c.Days},
+		&columnScanner{dst: &c.Nanos},
+	}
+}
+
+// columnScanner scans a single integer column of DurationColumns
+type columnScanner struct {
+	dst *int64
+}
+
+// Scan implements the sql.Scanner interface, treating NULL as zero
+func (s *columnScanner) Scan(value interface{}) error {
+	if value == nil {
+		*s.dst = 0
+		return nil
+	}
+
+	n, err := scanInt64(value)
+	if err != nil {
+		return err
+	}
+	*s.dst = n
+	return nil
+}
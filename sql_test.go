@@ -0,0 +1,143 @@
+package hdur
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestDuration_ScanNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Duration
+		wantErr bool
+	}{
+		{name: "int64", value: int64(90_000_000_000), want: Duration{Minutes: 1, Seconds: 30}},
+		{name: "negative int64", value: int64(-1_000_000_000), want: Duration{Seconds: -1}},
+		{name: "float64", value: float64(3_600_000_000_000), want: Duration{Hours: 1}},
+		{name: "fractional float64", value: 1.5, wantErr: true},
+		{name: "out of range float64", value: 1e19, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := d.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && d != tt.want {
+				t.Errorf("Scan() = %#v, want %#v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestNullDuration(t *testing.T) {
+	var n NullDuration
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error = %v", err)
+	}
+	if n.Valid {
+		t.Error("Scan(nil) should not be valid")
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("Value() = %v, %v, want nil", v, err)
+	}
+
+	if err := n.Scan("0s"); err != nil {
+		t.Fatalf("Scan(0s) error = %v", err)
+	}
+	if !n.Valid || !n.Duration.IsZero() {
+		t.Errorf("Scan(0s) = %+v, want valid zero duration", n)
+	}
+
+	if err := n.Scan(int64(1_500_000_000)); err != nil {
+		t.Fatalf("Scan(int64) error = %v", err)
+	}
+	if !n.Valid || n.Duration != (Duration{Seconds: 1, Nanos: 500_000_000}) {
+		t.Errorf("Scan(int64) = %+v", n)
+	}
+	if v, err := n.Value(); err != nil || v != "1s 500ms" {
+		t.Errorf("Value() = %v, %v, want 1s 500ms", v, err)
+	}
+
+	if err := n.Scan("invalid"); err == nil || n.Valid {
+		t.Errorf("Scan(invalid) = %+v, %v, want invalid with error", n, err)
+	}
+}
+
+func TestNanosDuration_SQL(t *testing.T) {
+	v, err := NanosDuration{Duration{Days: 1, Seconds: 1}}.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if v != int64(86_401_000_000_000) {
+		t.Errorf("Value() = %v, want 86401000000000", v)
+	}
+
+	if _, err := (NanosDuration{Years(1)}).Value(); err == nil {
+		t.Error("Value() expected error for calendar units")
+	}
+	if _, err := (NanosDuration{Duration{Days: 200000}}).Value(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Value() error = %v, want ErrOverflow", err)
+	}
+
+	// A nil pointer is written as NULL by database/sql
+	var ptr *NanosDuration
+	if v, err := driver.DefaultParameterConverter.ConvertValue(ptr); err != nil || v != nil {
+		t.Errorf("ConvertValue(nil) = %v, %v, want nil", v, err)
+	}
+
+	for _, value := range []interface{}{int64(60_000_000_000), float64(60_000_000_000), []byte("60000000000"), "60000000000", "1m"} {
+		var d NanosDuration
+		if err := d.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) error = %v", value, err)
+		}
+		if d.Duration != Minute {
+			t.Errorf("Scan(%v) = %v, want 1m", value, d.Duration)
+		}
+	}
+}
+
+func TestDurationColumns(t *testing.T) {
+	d := Duration{Years: 1, Months: 2, Days: 40, Hours: 30, Minutes: 1, Nanos: 5}
+
+	c := d.Columns()
+	want := DurationColumns{Months: 14, Days: 40, Nanos: 30*3_600_000_000_000 + 60_000_000_000 + 5}
+	if c != want {
+		t.Errorf("Columns() = %+v, want %+v", c, want)
+	}
+
+	args := c.Args()
+	var scanned DurationColumns
+	for i, dest := range scanned.Dest() {
+		if err := dest.(sql.Scanner).Scan(args[i]); err != nil {
+			t.Fatalf("Scan(%v) error = %v", args[i], err)
+		}
+	}
+	if scanned != c {
+		t.Errorf("scanned = %+v, want %+v", scanned, c)
+	}
+	if !scanned.Duration().Equal(d) {
+		t.Errorf("Duration() = %v, want %v", scanned.Duration(), d)
+	}
+
+	var fromDriver DurationColumns
+	dests := fromDriver.Dest()
+	values := []interface{}{float64(3), nil, []byte("1000000000")}
+	for i, dest := range dests {
+		if err := dest.(sql.Scanner).Scan(values[i]); err != nil {
+			t.Fatalf("Scan(%v) error = %v", values[i], err)
+		}
+	}
+	if fromDriver.Duration() != (Duration{Months: 3, Seconds: 1}) {
+		t.Errorf("Duration() = %#v", fromDriver.Duration())
+	}
+
+	if err := dests[0].(sql.Scanner).Scan(true); err == nil {
+		t.Error("Scan(bool) expected error")
+	}
+}
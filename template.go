@@ -0,0 +1,164 @@
+package hdur
+
+import (
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+)
+
+// FuncMap returns template functions for rendering durations. The same map
+// works with text/template and html/template:
+//
+//	tmpl := template.New("email").Funcs(hdur.FuncMap())
+//
+// Functions accepting a duration take a Duration, *Duration, time.Duration,
+// a string understood by ParseDuration, or a time.Time, which is measured
+// from the current time (positive in the future, negative in the past).
+//
+//	humanize   - long form: {{ humanize .TTL }} → "1 day 2 hours"
+//	relative   - largest unit relative to now: {{ relative .Expires }} → "in 3 days"
+//	format     - layout formatting: {{ .TTL | format "%d days" }}
+//	since      - duration since a time: {{ since .Created }}
+//	until      - duration until a time: {{ until .Expires }}
+//	add        - time after a duration: {{ add "1 month" .Start }}
+//	iso8601    - ISO 8601 form: {{ iso8601 .TTL }} → "P1DT2H"
+//	clock      - elapsed clock form: {{ clock .Elapsed }} → "26:00:05"
+//	timeTag    - HTML markup: {{ timeTag .TTL }} → <time datetime="P1DT2H">1 day 2 hours</time>
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"humanize": templateHumanize,
+		"relative": templateRelative,
+		"format":   templateFormat,
+		"since":    Since,
+		"until":    Until,
+		"add":      templateAdd,
+		"iso8601":  templateISO8601,
+		"clock":    templateClock,
+		"timeTag":  templateTimeTag,
+	}
+}
+
+// toDuration converts a template argument to a Duration
+func toDuration(v interface{}) (Duration, error) {
+	switch v := v.(type) {
+	case Duration:
+		return v, nil
+	case *Duration:
+		if v == nil {
+			return Duration{}, nil
+		}
+		return *v, nil
+	case time.Duration:
+		d := FromStandard(v)
+		d.normalize()
+		return d, nil
+	case string:
+		return ParseDuration(v)
+	case time.Time:
+		return Until(v), nil
+	default:
+		return Duration{}, fmt.Errorf("cannot use type %T as a Duration", v)
+	}
+}
+
+// largestComponent returns a duration holding only the most significant
+// non-zero component of d
+func largestComponent(d Duration) Duration {
+	switch {
+	case d.Years != 0:
+		return Duration{Years: d.Years}
+	case d.Months != 0:
+		return Duration{Months: d.Months}
+	case d.Days != 0:
+		return Duration{Days: d.Days}
+	case d.Hours != 0:
+		return Duration{Hours: d.Hours}
+	case d.Minutes != 0:
+		return Duration{Minutes: d.Minutes}
+	case d.Seconds != 0:
+		return Duration{Seconds: d.Seconds}
+	default:
+		return Duration{Nanos: d.Nanos}
+	}
+}
+
+func templateHumanize(v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	return d.Long(), nil
+}
+
+func templateRelative(v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	d.normalize()
+
+	switch {
+	case d.IsZero():
+		return "now", nil
+	case d.isNegativeDuration():
+		return largestComponent(d.abs()).Long() + " ago", nil
+	default:
+		return "in " + largestComponent(d).Long(), nil
+	}
+}
+
+func templateFormat(layout string, v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	return d.Format(layout), nil
+}
+
+func templateAdd(v interface{}, t time.Time) (time.Time, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.Add(t), nil
+}
+
+func templateISO8601(v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	return d.ISO8601(), nil
+}
+
+func templateClock(v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+
+	total := d.ToStandard()
+	sign := ""
+	if total < 0 {
+		sign = "-"
+		total = -total
+	}
+
+	hours := int64(total / time.Hour)
+	minutes := int64(total % time.Hour / time.Minute)
+	seconds := int64(total % time.Minute / time.Second)
+	return fmt.Sprintf("%s%d:%02d:%02d", sign, hours, minutes, seconds), nil
+}
+
+func templateTimeTag(v interface{}) (htmltemplate.HTML, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+
+	// #nosec G203 -- both values are escaped
+	return htmltemplate.HTML(fmt.Sprintf(`<time datetime="%s">%s</time>`,
+		html.EscapeString(d.ISO8601()), html.EscapeString(d.Long()))), nil
+}
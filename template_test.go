@@ -0,0 +1,129 @@
+package hdur
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestFuncMap(t *testing.T) {
+	// Times relative to now are in UTC and far from unit boundaries, so the
+	// results do not depend on the current date or daylight saving time
+	now := time.Now().UTC()
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	data := map[string]interface{}{
+		"TTL":      Duration{Days: 1, Hours: 2},
+		"Ptr":      &Duration{Minutes: 5},
+		"Std":      90 * time.Minute,
+		"Str":      "1 year 2 months",
+		"Start":    start,
+		"Future":   now.Add(73 * time.Hour),
+		"Past":     now.Add(-2*time.Hour - 30*time.Minute),
+		"Recent":   now.Add(-49 * time.Hour),
+		"Soon":     now.Add(49*time.Hour + 30*time.Minute),
+		"Elapsed":  Duration{Days: 1, Hours: 2, Seconds: 5},
+		"Negative": Duration{Minutes: -1, Seconds: -5},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"humanize duration", `{{ humanize .TTL }}`, "1 day 2 hours"},
+		{"humanize pointer", `{{ humanize .Ptr }}`, "5 minutes"},
+		{"humanize standard", `{{ humanize .Std }}`, "1 hour 30 minutes"},
+		{"humanize string", `{{ humanize .Str }}`, "1 year 2 months"},
+		{"relative future", `{{ relative .Future }}`, "in 3 days"},
+		{"relative past", `{{ relative .Past }}`, "2 hours ago"},
+		{"relative zero", `{{ relative "0s" }}`, "now"},
+		{"format", `{{ .TTL | format "%d days %h hours" }}`, "1 days 2 hours"},
+		{"add", `{{ (add "1 month" .Start).Format "2006-01-02" }}`, "2024-02-29"},
+		{"since", `{{ (since .Recent).Days }}d {{ (since .Recent).Hours }}h`, "2d 1h"},
+		{"until", `{{ (until .Soon).Days }}d {{ (until .Soon).Hours }}h`, "2d 1h"},
+		{"iso8601", `{{ iso8601 .TTL }}`, "P1DT2H"},
+		{"clock", `{{ clock .Elapsed }}`, "26:00:05"},
+		{"clock negative", `{{ clock .Negative }}`, "-0:01:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(FuncMap()).Parse(tt.template)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncMap_SinceUntil(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-49*time.Hour - 30*time.Minute)
+	future := now.Add(49*time.Hour + 30*time.Minute)
+
+	tmpl := template.Must(template.New("test").Funcs(FuncMap()).
+		Parse(`{{ with since .Past }}{{ .Days }} {{ .Hours }} {{ .Minutes }}{{ end }}|` +
+			`{{ with until .Future }}{{ .Days }} {{ .Hours }} {{ .Minutes }}{{ end }}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"Past": past, "Future": future}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The minutes are far from a boundary, so measuring a moment later with
+	// Since and Until gives the same components
+	since, until := Since(past), Until(future)
+	want := fmt.Sprintf("%d %d %d|%d %d %d", since.Days, since.Hours, since.Minutes,
+		until.Days, until.Hours, until.Minutes)
+	if got := buf.String(); got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestFuncMap_HTML(t *testing.T) {
+	tmpl, err := htmltemplate.New("test").Funcs(FuncMap()).
+		Parse(`<p>{{ timeTag .TTL }}</p><p>{{ humanize .TTL }}</p>`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"TTL": Duration{Days: 1, Hours: 2}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := `<p><time datetime="P1DT2H">1 day 2 hours</time></p><p>1 day 2 hours</p>`
+	if got := buf.String(); got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestFuncMap_Errors(t *testing.T) {
+	templates := []string{
+		`{{ humanize 42 }}`,
+		`{{ humanize "not a duration" }}`,
+		`{{ iso8601 true }}`,
+	}
+
+	for _, text := range templates {
+		t.Run(text, func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(FuncMap()).Parse(text))
+			err := tmpl.Execute(&bytes.Buffer{}, nil)
+			if err == nil || !strings.Contains(err.Error(), "error calling") {
+				t.Errorf("Execute() error = %v, want function call error", err)
+			}
+		})
+	}
+}
@@ -17,49 +17,137 @@ func (d Duration) IsZero() bool {
 		d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 && d.Nanos == 0
 }
 
-// Until returns the duration until the given time
+// Until returns the duration until the given time, measured in t's location
 func Until(t time.Time) Duration {
 	return Sub(t, time.Now())
 }
 
-// Since returns the duration since the given time
+// Since returns the duration since the given time, measured in t's location
 func Since(t time.Time) Duration {
-	return Sub(time.Now(), t)
+	return SubIn(time.Now(), t, t.Location())
 }
 
-// Between returns the duration between two times
+// Between returns the duration between two times, measured in t1's location
 func Between(t1, t2 time.Time) Duration {
-	return Sub(t2, t1)
+	return SubIn(t2, t1, t1.Location())
 }
 
-// Add adds the duration to a time and returns the resulting time
-func (d Duration) Add(t time.Time) time.Time {
-	// First add years, which is straightforward
-	t = t.AddDate(d.Years, 0, 0)
+// Policy selects how adding years or months treats a day of month that does
+// not exist in the target month, such as January 31 plus one month
+type Policy int
 
-	// Then add months while preserving the original day of month when possible
-	if d.Months > 0 {
-		year, month, day := t.Date()
-		hour, min, sec := t.Clock()
-		nsec := t.Nanosecond()
+const (
+	// PolicyClamp uses the last day of the target month: January 31 plus one
+	// month is February 28, and February 29 plus one year is February 28.
+	// This is the policy used by Add.
+	PolicyClamp Policy = iota
+	// PolicyOverflow carries the extra days into the following month, like
+	// time.AddDate: January 31 plus one month is March 3, and February 29 plus
+	// one year is March 1.
+	PolicyOverflow
+	// PolicyStickyEndOfMonth keeps times on the last day of a month on the
+	// last day of the target month, and clamps otherwise: February 28, 2023
+	// plus one month is March 31, and plus one year is February 29, 2024.
+	PolicyStickyEndOfMonth
+)
 
-		// Calculate target month and year
-		totalMonths := int(month) + d.Months
-		targetYear := year + (totalMonths-1)/12
-		targetMonth := time.Month((totalMonths-1)%12 + 1)
+// addMonths adds months to t, resolving a day of month that does not exist
+// in the target month using policy. Negative months count backward with the
+// same rules.
+func addMonths(t time.Time, months int, policy Policy) time.Time {
+	if months == 0 {
+		return t
+	}
+
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	nsec := t.Nanosecond()
 
-		// Get the last day of the target month
-		lastDay := time.Date(targetYear, targetMonth, 1, 0, 0, 0, 0, t.Location()).
-			AddDate(0, 1, -1).Day()
+	// Calculate target month and year
+	yearCarry, monthIndex := floorDivMod(int(month)-1+months, 12)
+	targetYear := year + yearCarry
+	targetMonth := time.Month(monthIndex + 1)
+	lastDay := getDaysInMonth(targetYear, targetMonth)
 
-		// Use the original day unless it would be invalid in the target month
+	switch policy {
+	case PolicyOverflow:
+		// time.Date normalizes days past the end of the month
+	case PolicyStickyEndOfMonth:
+		if day == getDaysInMonth(year, month) || day > lastDay {
+			day = lastDay
+		}
+	default:
 		if day > lastDay {
 			day = lastDay
 		}
+	}
+
+	return time.Date(targetYear, targetMonth, day, hour, min, sec, nsec, t.Location())
+}
+
+// Mode selects whether duration components are applied to the wall clock of
+// a time's location or as elapsed time. The two differ only across
+// daylight saving transitions and other changes of UTC offset.
+type Mode int
 
-		t = time.Date(targetYear, targetMonth, day, hour, min, sec, nsec, t.Location())
+const (
+	// ModeCalendar applies years, months and days to the wall clock and
+	// hours and smaller units as elapsed time, like time.AddDate followed by
+	// time.Add: one day after noon is noon the next day, even if that day
+	// is 23 or 25 hours long, while 24 hours is always 24 elapsed hours.
+	// This is the mode used by Add.
+	ModeCalendar Mode = iota
+	// ModeWallClock applies every component to the wall clock, so 24 hours
+	// after noon is noon the next day. A wall clock time that does not
+	// exist or occurs twice is resolved as by time.Date.
+	ModeWallClock
+	// ModeElapsed applies every component as elapsed time: a day is always
+	// 24 hours, and years and months are as long as they are in UTC.
+	ModeElapsed
+)
+
+// Add adds the duration to a time and returns the resulting time.
+// Components are applied from largest to smallest: years and months together
+// (clamping the day of month to the end of a shorter month, in either
+// direction, so February 29 plus one year is February 28), then days and
+// finally the time of day components. Years, months and days follow the wall
+// clock and smaller units elapsed time; use AddMode or AddWith for other
+// behavior.
+func (d Duration) Add(t time.Time) time.Time {
+	return d.add(t, PolicyClamp, ModeCalendar)
+}
+
+// AddWith is like Add but resolves days of month that do not exist in the
+// target month using policy. Years are applied as twelve months, so the
+// policy treats leap days the same way as month ends.
+func (d Duration) AddWith(t time.Time, policy Policy) time.Time {
+	return d.add(t, policy, ModeCalendar)
+}
+
+// AddMode is like Add but applies the components to the wall clock or as
+// elapsed time according to mode
+func (d Duration) AddMode(t time.Time, mode Mode) time.Time {
+	return d.add(t, PolicyClamp, mode)
+}
+
+// add applies d to t, resolving month ends with policy and wall clock
+// changes with mode
+func (d Duration) add(t time.Time, policy Policy, mode Mode) time.Time {
+	switch mode {
+	case ModeElapsed:
+		// UTC has no offset changes, so its wall clock is elapsed time
+		return d.add(t.UTC(), policy, ModeCalendar).In(t.Location())
+	case ModeWallClock:
+		t = addMonths(t, d.Years*12+d.Months, policy)
+		year, month, day := t.Date()
+		hour, min, sec := t.Clock()
+		return time.Date(year, month, day+d.Days, hour+d.Hours, min+d.Minutes,
+			sec+d.Seconds, t.Nanosecond()+d.Nanos, t.Location())
 	}
 
+	// First add years and months, resolving the day of month with policy
+	t = addMonths(t, d.Years*12+d.Months, policy)
+
 	// Finally add the remaining components
 	return t.AddDate(0, 0, d.Days).
 		Add(time.Duration(d.Hours)*time.Hour +
@@ -68,75 +156,134 @@ func (d Duration) Add(t time.Time) time.Time {
 			time.Duration(d.Nanos)*time.Nanosecond)
 }
 
+// SubtractFrom subtracts the duration from a time and returns the resulting
+// time. It is equivalent to d.Neg().Add(t), so components are removed from
+// largest to smallest with the same day-of-month clamping as Add:
+// Months(1).SubtractFrom(March 31) is the last day of February.
+func (d Duration) SubtractFrom(t time.Time) time.Time {
+	return d.Neg().Add(t)
+}
+
 // Sub returns the duration between two times, attempting to preserve month and year units.
 // The duration will be negative if t1 is before t2, following the behavior of time.Sub.
+// Every component is measured on the wall clock of t1's location, so t2 is
+// converted to it first; use SubIn to measure in another location and
+// SubMode to measure elapsed time instead.
+//
+// When t1 is at or after t2, Sub(t1, t2).Add(t2) == t1. Months are counted
+// the way Add applies them, clamping to the end of shorter months, so from
+// January 31 to March 1, 2024 is one month and one day: January 31 plus one
+// month is February 29. The property holds as long as the UTC offset of the
+// location does not change between the two times; across a daylight saving
+// transition, use SubMode with ModeCalendar and AddMode instead. When t1 is
+// before t2 the result is the negation of Sub(t2, t1), and adding it to t2
+// gives t1 only when no month end is clamped.
 func Sub(t1, t2 time.Time) Duration {
-	// Track if we need to negate the result
-	needsNegation := t1.Before(t2)
+	return wallClockSub(t1, t2.In(t1.Location()))
+}
 
-	// Ensure a is the later time for calculation
-	a, b := t1, t2
-	if needsNegation {
-		a, b = t2, t1
+// SubIn is like Sub but measures both times on the wall clock of loc. The
+// location decides where days and months begin: from February 29 at 15:30
+// UTC to March 31 at 15:30 UTC is one month and two days in UTC but one month
+// in Asia/Tokyo, where those times fall on March 1 and April 1.
+func SubIn(t1, t2 time.Time, loc *time.Location) Duration {
+	return Sub(t1.In(loc), t2.In(loc))
+}
+
+// SubMode returns the duration between two times, measuring components on
+// the wall clock or as elapsed time according to mode:
+//
+//   - ModeWallClock compares the wall clock readings, like Sub, so noon to
+//     noon the next day is one day and 01:00 to 03:00 is two hours, however
+//     much time passed.
+//   - ModeElapsed measures in UTC, so a 23-hour day is 23 hours.
+//   - ModeCalendar counts whole days on the wall clock and the rest as
+//     elapsed time, so that adding the result to t2 with the same mode gives
+//     t1 back.
+//
+// Like Sub, the wall clock is that of t1's location.
+func SubMode(t1, t2 time.Time, mode Mode) Duration {
+	t2 = t2.In(t1.Location())
+	switch mode {
+	case ModeElapsed:
+		return wallClockSub(t1.UTC(), t2.UTC())
+	case ModeWallClock:
+		return wallClockSub(t1, t2)
 	}
 
-	years := a.Year() - b.Year()
-	months := int(a.Month() - b.Month())
-	days := a.Day() - b.Day()
-	hours := a.Hour() - b.Hour()
-	minutes := a.Minute() - b.Minute()
-	seconds := a.Second() - b.Second()
-	nanos := a.Nanosecond() - b.Nanosecond()
-
-	// Normalize negative values
-	if nanos < 0 {
-		nanos += 1000000000
-		seconds--
+	if t1.Before(t2) {
+		return SubMode(t2, t1, mode).Neg()
 	}
-	if seconds < 0 {
-		seconds += 60
-		minutes--
+
+	// Take the whole days from the wall clock, stepping back a day if the
+	// wall clock difference overshoots because of a shorter day
+	wall := wallClockSub(t1, t2)
+	calendar := Duration{Years: wall.Years, Months: wall.Months, Days: wall.Days}
+	mid := calendar.Add(t2)
+	for mid.After(t1) {
+		calendar.Days--
+		mid = calendar.Add(t2)
 	}
-	if minutes < 0 {
-		minutes += 60
-		hours--
+
+	// The rest is elapsed time, kept in hours even past a day so that it is
+	// not applied as a calendar day
+	rest := t1.Sub(mid)
+	calendar.Hours = int(rest / time.Hour)
+	calendar.Minutes = int(rest % time.Hour / time.Minute)
+	calendar.Seconds = int(rest % time.Minute / time.Second)
+	calendar.Nanos = int(rest % time.Second)
+	return calendar
+}
+
+// civilDate returns the calendar date of t as midnight UTC, so that dates
+// can be compared and subtracted without regard to location
+func civilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// wallClockSub returns the difference between the wall clock readings of
+// t1 and t2, in the form Add undoes: adding the result to the earlier time
+// applies the months, then the days, then the time of day.
+func wallClockSub(t1, t2 time.Time) Duration {
+	if t1.Before(t2) {
+		return wallClockSub(t2, t1).Neg()
 	}
-	if hours < 0 {
-		hours += 24
-		days--
+	a, b := t1, t2
+
+	// Borrow a day when a's time of day is earlier than b's
+	dateA := civilDate(a)
+	clock := wallClock(a) - wallClock(b)
+	if clock < 0 {
+		clock += 24 * time.Hour
+		dateA = dateA.AddDate(0, 0, -1)
 	}
-	if days < 0 {
-		// Use the previous month's length
-		prevMonth := a.AddDate(0, -1, 0)
-		days += getDaysInMonth(prevMonth.Year(), prevMonth.Month())
+
+	// Take as many whole months as fit, applying them the way Add does so
+	// that a clamped month end never overshoots
+	dateB := civilDate(b)
+	months := (dateA.Year()-dateB.Year())*12 + int(dateA.Month()-dateB.Month())
+	mid := addMonths(dateB, months, PolicyClamp)
+	if mid.After(dateA) {
 		months--
+		mid = addMonths(dateB, months, PolicyClamp)
 	}
-	if months < 0 {
-		months += 12
-		years--
-	}
+	days := int(dateA.Sub(mid) / (24 * time.Hour))
 
-	result := Duration{
-		Years:   years,
-		Months:  months,
+	return Duration{
+		Years:   months / 12,
+		Months:  months % 12,
 		Days:    days,
-		Hours:   hours,
-		Minutes: minutes,
-		Seconds: seconds,
-		Nanos:   nanos,
-	}
-
-	if needsNegation {
-		result = Duration{
-			Years:   -years,
-			Months:  -months,
-			Days:    -days,
-			Hours:   -hours,
-			Minutes: -minutes,
-			Seconds: -seconds,
-			Nanos:   -nanos,
-		}
+		Hours:   int(clock / time.Hour),
+		Minutes: int(clock % time.Hour / time.Minute),
+		Seconds: int(clock % time.Minute / time.Second),
+		Nanos:   int(clock % time.Second),
 	}
+}
 
-	return result
+// wallClock returns the time of day shown on t's wall clock
+func wallClock(t time.Time) time.Duration {
+	hour, min, sec := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
 }
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 	"time"
+	_ "time/tzdata"
 )
 
 func BenchmarkDurationAdd(b *testing.B) {
@@ -348,3 +349,550 @@ func TestDurationTimeOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_Add_NegativeMonths(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        Duration
+		start    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "subtract one month",
+			d:        Months(-1),
+			start:    time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.February, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp to end of february",
+			d:        Months(-1),
+			start:    time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "across year boundary",
+			d:        Months(-2),
+			start:    time.Date(2024, time.January, 31, 8, 30, 0, 0, time.UTC),
+			expected: time.Date(2023, time.November, 30, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "negative years and months",
+			d:        Duration{Years: -1, Months: -1},
+			start:    time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day minus one year",
+			d:        Years(-1),
+			start:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day plus one year",
+			d:        Years(1),
+			start:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "many months back",
+			d:        Months(-25),
+			start:    time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2022, time.April, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Add(tt.start); !got.Equal(tt.expected) {
+				t.Errorf("Add() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDuration_SubtractFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        Duration
+		start    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "one month from march 31",
+			d:        Months(1),
+			start:    time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "complex duration",
+			d:        MustParseDuration("1 year 1 month 1 day 1 hour"),
+			start:    time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "negative duration adds",
+			d:        Days(-2),
+			start:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.SubtractFrom(tt.start); !got.Equal(tt.expected) {
+				t.Errorf("SubtractFrom() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// referenceAddMonths is a straightforward month addition used to check
+// Add: step to the first of the target month, then clamp the day
+func referenceAddMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	first = first.AddDate(0, months, 0)
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func TestDuration_Add_MatchesReference(t *testing.T) {
+	start := time.Date(2019, time.January, 1, 6, 30, 0, 0, time.UTC)
+	end := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for months := -30; months <= 30; months++ {
+			got := Months(float64(months)).Add(day)
+			want := referenceAddMonths(day, months)
+			if !got.Equal(want) {
+				t.Fatalf("Months(%d).Add(%v) = %v, want %v", months, day, got, want)
+			}
+
+			if months != 0 {
+				back := Months(float64(-months)).SubtractFrom(day)
+				if !back.Equal(want) {
+					t.Fatalf("Months(%d).SubtractFrom(%v) = %v, want %v", -months, day, back, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDuration_AddWith(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		d        Duration
+		start    time.Time
+		clamp    time.Time
+		overflow time.Time
+		sticky   time.Time
+	}{
+		{
+			name:     "january 31 plus one month",
+			d:        Months(1),
+			start:    date(2023, time.January, 31),
+			clamp:    date(2023, time.February, 28),
+			overflow: date(2023, time.March, 3),
+			sticky:   date(2023, time.February, 28),
+		},
+		{
+			name:     "february 28 plus one month",
+			d:        Months(1),
+			start:    date(2023, time.February, 28),
+			clamp:    date(2023, time.March, 28),
+			overflow: date(2023, time.March, 28),
+			sticky:   date(2023, time.March, 31),
+		},
+		{
+			name:     "leap day plus one year",
+			d:        Years(1),
+			start:    date(2024, time.February, 29),
+			clamp:    date(2025, time.February, 28),
+			overflow: date(2025, time.March, 1),
+			sticky:   date(2025, time.February, 28),
+		},
+		{
+			name:     "end of february plus one year",
+			d:        Years(1),
+			start:    date(2023, time.February, 28),
+			clamp:    date(2024, time.February, 28),
+			overflow: date(2024, time.February, 28),
+			sticky:   date(2024, time.February, 29),
+		},
+		{
+			name:     "april 30 minus one month",
+			d:        Months(-1),
+			start:    date(2024, time.April, 30),
+			clamp:    date(2024, time.March, 30),
+			overflow: date(2024, time.March, 30),
+			sticky:   date(2024, time.March, 31),
+		},
+		{
+			name:     "mid month is unaffected",
+			d:        MustParseDuration("1y 1mo 1d"),
+			start:    date(2024, time.January, 15),
+			clamp:    date(2025, time.February, 16),
+			overflow: date(2025, time.February, 16),
+			sticky:   date(2025, time.February, 16),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for policy, want := range map[Policy]time.Time{
+				PolicyClamp:            tt.clamp,
+				PolicyOverflow:         tt.overflow,
+				PolicyStickyEndOfMonth: tt.sticky,
+			} {
+				if got := tt.d.AddWith(tt.start, policy); !got.Equal(want) {
+					t.Errorf("AddWith(policy %d) = %v, want %v", policy, got, want)
+				}
+			}
+
+			if got := tt.d.Add(tt.start); !got.Equal(tt.clamp) {
+				t.Errorf("Add() = %v, want %v", got, tt.clamp)
+			}
+		})
+	}
+}
+
+// mustLoadLocation loads a time zone from the embedded tzdata
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) error = %v", name, err)
+	}
+	return loc
+}
+
+func TestDuration_AddMode(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name      string
+		d         Duration
+		start     time.Time
+		calendar  time.Time
+		wallClock time.Time
+		elapsed   time.Time
+	}{
+		{
+			name:      "day across spring forward",
+			d:         Days(1),
+			start:     time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork),
+			calendar:  time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork),
+			wallClock: time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork),
+			elapsed:   time.Date(2024, time.March, 10, 13, 0, 0, 0, newYork),
+		},
+		{
+			name:      "24 hours across spring forward",
+			d:         Duration{Hours: 24},
+			start:     time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork),
+			calendar:  time.Date(2024, time.March, 10, 13, 0, 0, 0, newYork),
+			wallClock: time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork),
+			elapsed:   time.Date(2024, time.March, 10, 13, 0, 0, 0, newYork),
+		},
+		{
+			name:      "day across fall back",
+			d:         Days(1),
+			start:     time.Date(2024, time.November, 2, 12, 0, 0, 0, newYork),
+			calendar:  time.Date(2024, time.November, 3, 12, 0, 0, 0, newYork),
+			wallClock: time.Date(2024, time.November, 3, 12, 0, 0, 0, newYork),
+			elapsed:   time.Date(2024, time.November, 3, 11, 0, 0, 0, newYork),
+		},
+		{
+			name:      "hours across fall back",
+			d:         Hours(3),
+			start:     time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork),
+			calendar:  time.Date(2024, time.November, 3, 2, 0, 0, 0, newYork),
+			wallClock: time.Date(2024, time.November, 3, 3, 0, 0, 0, newYork),
+			elapsed:   time.Date(2024, time.November, 3, 2, 0, 0, 0, newYork),
+		},
+		{
+			name:      "month across spring forward",
+			d:         Months(1),
+			start:     time.Date(2024, time.March, 1, 0, 0, 0, 0, newYork),
+			calendar:  time.Date(2024, time.April, 1, 0, 0, 0, 0, newYork),
+			wallClock: time.Date(2024, time.April, 1, 0, 0, 0, 0, newYork),
+			elapsed:   time.Date(2024, time.April, 1, 1, 0, 0, 0, newYork),
+		},
+		{
+			name:      "day across british summer time",
+			d:         Days(1),
+			start:     time.Date(2024, time.March, 30, 12, 0, 0, 0, london),
+			calendar:  time.Date(2024, time.March, 31, 12, 0, 0, 0, london),
+			wallClock: time.Date(2024, time.March, 31, 12, 0, 0, 0, london),
+			elapsed:   time.Date(2024, time.March, 31, 13, 0, 0, 0, london),
+		},
+		{
+			name:      "day and hours across the end of british summer time",
+			d:         Duration{Days: 1, Hours: 2},
+			start:     time.Date(2024, time.October, 26, 12, 0, 0, 0, london),
+			calendar:  time.Date(2024, time.October, 27, 14, 0, 0, 0, london),
+			wallClock: time.Date(2024, time.October, 27, 14, 0, 0, 0, london),
+			elapsed:   time.Date(2024, time.October, 27, 13, 0, 0, 0, london),
+		},
+		{
+			name:      "negative day",
+			d:         Days(-1),
+			start:     time.Date(2024, time.October, 28, 0, 30, 0, 0, london),
+			calendar:  time.Date(2024, time.October, 27, 0, 30, 0, 0, london),
+			wallClock: time.Date(2024, time.October, 27, 0, 30, 0, 0, london),
+			elapsed:   time.Date(2024, time.October, 27, 0, 30, 0, 0, time.UTC), // 01:30 BST
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for mode, want := range map[Mode]time.Time{
+				ModeCalendar:  tt.calendar,
+				ModeWallClock: tt.wallClock,
+				ModeElapsed:   tt.elapsed,
+			} {
+				got := tt.d.AddMode(tt.start, mode)
+				if !got.Equal(want) {
+					t.Errorf("AddMode(mode %d) = %v, want %v", mode, got, want)
+				}
+				if got.Location() != tt.start.Location() {
+					t.Errorf("AddMode(mode %d) location = %v, want %v", mode, got.Location(), tt.start.Location())
+				}
+			}
+
+			if got := tt.d.Add(tt.start); !got.Equal(tt.calendar) {
+				t.Errorf("Add() = %v, want %v", got, tt.calendar)
+			}
+		})
+	}
+}
+
+func TestSubMode(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name      string
+		t1        time.Time
+		t2        time.Time
+		calendar  Duration
+		wallClock Duration
+		elapsed   Duration
+	}{
+		{
+			name:      "23 hour day",
+			t1:        time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork),
+			t2:        time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork),
+			calendar:  Duration{Days: 1},
+			wallClock: Duration{Days: 1},
+			elapsed:   Duration{Hours: 23},
+		},
+		{
+			name:      "less than a day across spring forward",
+			t1:        time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork),
+			t2:        time.Date(2024, time.March, 9, 13, 0, 0, 0, newYork),
+			calendar:  Duration{Hours: 22},
+			wallClock: Duration{Hours: 23},
+			elapsed:   Duration{Hours: 22},
+		},
+		{
+			name:      "25 hour day",
+			t1:        time.Date(2024, time.October, 27, 12, 0, 0, 0, london),
+			t2:        time.Date(2024, time.October, 26, 12, 0, 0, 0, london),
+			calendar:  Duration{Days: 1},
+			wallClock: Duration{Days: 1},
+			elapsed:   Duration{Days: 1, Hours: 1},
+		},
+		{
+			name:      "negative",
+			t1:        time.Date(2024, time.October, 26, 12, 0, 0, 0, london),
+			t2:        time.Date(2024, time.October, 27, 13, 0, 0, 0, london),
+			calendar:  Duration{Days: -1, Hours: -1},
+			wallClock: Duration{Days: -1, Hours: -1},
+			elapsed:   Duration{Days: -1, Hours: -2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for mode, want := range map[Mode]Duration{
+				ModeCalendar:  tt.calendar,
+				ModeWallClock: tt.wallClock,
+				ModeElapsed:   tt.elapsed,
+			} {
+				if got := SubMode(tt.t1, tt.t2, mode); !got.Equal(want) {
+					t.Errorf("SubMode(mode %d) = %v, want %v", mode, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSubMode_RoundTrip(t *testing.T) {
+	for _, name := range []string{"America/New_York", "Europe/London"} {
+		loc := mustLoadLocation(t, name)
+		for _, start := range []time.Time{
+			time.Date(2024, time.March, 8, 0, 30, 0, 0, loc),
+			time.Date(2024, time.March, 29, 22, 15, 0, 0, loc),
+			time.Date(2024, time.October, 25, 23, 45, 0, 0, loc),
+			time.Date(2024, time.November, 1, 1, 30, 0, 0, loc),
+		} {
+			// Step through the transitions in 15 minute increments
+			for step := 0; step < 4*24*4; step++ {
+				end := start.Add(time.Duration(step) * 15 * time.Minute)
+				for _, mode := range []Mode{ModeCalendar, ModeElapsed} {
+					d := SubMode(end, start, mode)
+					if got := d.AddMode(start, mode); !got.Equal(end) {
+						t.Fatalf("%s: SubMode(%v, %v, mode %d) = %v, which adds back to %v",
+							name, end, start, mode, d, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSub_Locations(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name string
+		got  Duration
+		want Duration
+	}{
+		{
+			name: "UTC and Tokyo",
+			got:  Sub(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 8, 0, 0, 0, tokyo)),
+			want: Duration{Hours: 1},
+		},
+		{
+			name: "Tokyo and UTC",
+			got:  Sub(time.Date(2024, 1, 2, 8, 0, 0, 0, tokyo), time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)),
+			want: Duration{Hours: 1},
+		},
+		{
+			name: "same instant",
+			got:  Sub(time.Date(2024, 6, 1, 9, 0, 0, 0, tokyo), time.Date(2024, 5, 31, 20, 0, 0, 0, newYork)),
+			want: Duration{},
+		},
+		{
+			name: "measured in the first location",
+			got:  Sub(time.Date(2024, 3, 31, 15, 30, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 30, 0, 0, tokyo)),
+			want: Duration{Months: 1, Days: 2},
+		},
+		{
+			name: "measured in Tokyo",
+			got:  SubIn(time.Date(2024, 3, 31, 15, 30, 0, 0, time.UTC), time.Date(2024, 2, 29, 15, 30, 0, 0, time.UTC), tokyo),
+			want: Duration{Months: 1},
+		},
+		{
+			name: "measured in UTC",
+			got:  SubIn(time.Date(2024, 4, 1, 0, 30, 0, 0, tokyo), time.Date(2024, 3, 1, 0, 30, 0, 0, tokyo), time.UTC),
+			want: Duration{Months: 1, Days: 2},
+		},
+		{
+			name: "between uses the first location",
+			got:  Between(time.Date(2024, 3, 1, 0, 30, 0, 0, tokyo), time.Date(2024, 3, 31, 15, 30, 0, 0, time.UTC)),
+			want: Duration{Months: 1},
+		},
+		{
+			name: "negative",
+			got:  Sub(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 10, 0, 0, 0, tokyo)),
+			want: Duration{Hours: -2},
+		},
+		{
+			name: "sub mode",
+			got:  SubMode(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 8, 0, 0, 0, tokyo), ModeCalendar),
+			want: Duration{Hours: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Since and Until measure in the location of their argument
+	past := time.Now().In(tokyo).Add(-90 * time.Minute)
+	if got := Since(past).InMinutes(); math.Abs(got-90) > 1 {
+		t.Errorf("Since().InMinutes() = %v, want about 90", got)
+	}
+	if got := Until(past.Add(3 * time.Hour)).InMinutes(); math.Abs(got-90) > 1 {
+		t.Errorf("Until().InMinutes() = %v, want about 90", got)
+	}
+}
+
+func TestSub_AddInverse(t *testing.T) {
+	tests := []struct {
+		name string
+		a    time.Time
+		b    time.Time
+		want Duration
+	}{
+		{"month end to next month", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Duration{Months: 1, Days: 1}},
+		{"month end to shorter month end", time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), Duration{Months: 1}},
+		{"leap day to next year", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), Duration{Years: 1, Days: 1}},
+		{"leap day to next february", time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), Duration{Years: 1}},
+		{"borrowed day at month start", time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 2, 0, 0, 0, time.UTC), Duration{Months: 1, Hours: 23}},
+		{"borrowed day across year", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 31, 0, 0, 0, 1, time.UTC), Duration{Hours: 23, Minutes: 59, Seconds: 59, Nanos: 999999999}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sub(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("Sub() = %#v, want %#v", got, tt.want)
+			}
+			if end := got.Add(tt.b); !end.Equal(tt.a) {
+				t.Errorf("Sub().Add() = %v, want %v", end, tt.a)
+			}
+			if neg := Sub(tt.b, tt.a); neg != tt.want.Neg() {
+				t.Errorf("reversed Sub() = %#v, want %#v", neg, tt.want.Neg())
+			}
+		})
+	}
+}
+
+func TestSub_AddInverse_Exhaustive(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive test in short mode")
+	}
+
+	first := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+	clocks := []struct{ start, end time.Duration }{
+		{0, 0},
+		{15 * time.Hour, 9*time.Hour + 30*time.Minute},
+		{9 * time.Hour, 15*time.Hour + 1},
+	}
+
+	for b := first; !b.After(last); b = b.AddDate(0, 0, 1) {
+		for days := 0; days <= 500; days++ {
+			for _, clock := range clocks {
+				start := b.Add(clock.start)
+				end := b.AddDate(0, 0, days).Add(clock.end)
+				if end.Before(start) {
+					continue
+				}
+
+				d := Sub(end, start)
+				if got := d.Add(start); !got.Equal(end) {
+					t.Fatalf("Sub(%v, %v) = %v, which adds back to %v", end, start, d, got)
+				}
+				if d.isNegativeDuration() || d.Months < 0 || d.Days < 0 || d.Days > 30 || d.Hours < 0 {
+					t.Fatalf("Sub(%v, %v) = %#v is not balanced", end, start, d)
+				}
+			}
+		}
+	}
+}
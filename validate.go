@@ -0,0 +1,212 @@
+package hdur
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Constraint describes the values a duration may take. Zero fields impose
+// no restriction.
+type Constraint struct {
+	Min        *Duration // Min is the smallest allowed value, inclusive
+	Max        *Duration // Max is the largest allowed value, inclusive
+	MultipleOf Duration  // MultipleOf requires the value to be an exact multiple
+	NoCalendar bool      // NoCalendar rejects values with years or months
+	NonZero    bool      // NonZero rejects the zero duration
+	Positive   bool      // Positive requires a value greater than zero
+}
+
+// ParseConstraint parses a comma-separated constraint tag such as
+// "min=1s,max=5m,nocalendar,nonzero,positive,multipleof=15m"
+func ParseConstraint(tag string) (Constraint, error) {
+	c := Constraint{}
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(rule), "=")
+
+		var d Duration
+		if hasArg {
+			var err error
+			if d, err = ParseDuration(arg); err != nil {
+				return Constraint{}, fmt.Errorf("invalid %s constraint: %w", name, err)
+			}
+		}
+
+		if err := c.applyRule(rule, name, d, hasArg); err != nil {
+			return Constraint{}, err
+		}
+	}
+	return c, nil
+}
+
+// applyRule sets the field of c for a single rule of a constraint tag. The
+// rule's name and parsed argument d are given separately, and d is only
+// meaningful when hasArg is set.
+func (c *Constraint) applyRule(rule, name string, d Duration, hasArg bool) error {
+	switch {
+	case name == "":
+	case name == "min" && hasArg:
+		c.Min = &d
+	case name == "max" && hasArg:
+		c.Max = &d
+	case name == "multipleof" && hasArg:
+		if d.IsZero() {
+			return fmt.Errorf("invalid multipleof constraint: must not be zero")
+		}
+		c.MultipleOf = d
+	case name == "nocalendar" && !hasArg:
+		c.NoCalendar = true
+	case name == "nonzero" && !hasArg:
+		c.NonZero = true
+	case name == "positive" && !hasArg:
+		c.Positive = true
+	default:
+		return fmt.Errorf("unknown constraint: %s", rule)
+	}
+	return nil
+}
+
+// isMultipleOf reports whether d is an exact multiple of m. Durations of
+// only years and months are compared in months; anything else is compared in
+// nanoseconds, counting days as 24 hours, and cannot contain calendar units.
+func isMultipleOf(d, m Duration) bool {
+	if m.Years != 0 || m.Months != 0 {
+		if m.Days != 0 || m.fixedNanos() != 0 || d.Days != 0 || d.fixedNanos() != 0 {
+			return false
+		}
+		return (d.Years*12+d.Months)%(m.Years*12+m.Months) == 0
+	}
+
+	if d.Years != 0 || d.Months != 0 || m.fixedNanos() == 0 {
+		return false
+	}
+	return d.fixedNanos()%m.fixedNanos() == 0
+}
+
+// Check returns an error describing the first rule d violates
+func (c Constraint) Check(d Duration) error {
+	switch {
+	case c.NoCalendar && (d.Years != 0 || d.Months != 0):
+		return fmt.Errorf("%s must not use years or months", d)
+	case c.NonZero && d.IsZero():
+		return fmt.Errorf("must not be zero")
+	case c.Positive && (d.IsZero() || d.isNegativeDuration()):
+		return fmt.Errorf("%s must be positive", d)
+	case c.Min != nil && d.Less(*c.Min):
+		return fmt.Errorf("%s must be at least %s", d, *c.Min)
+	case c.Max != nil && d.Greater(*c.Max):
+		return fmt.Errorf("%s must be at most %s", d, *c.Max)
+	case !c.MultipleOf.IsZero() && !isMultipleOf(d, c.MultipleOf):
+		return fmt.Errorf("%s must be a multiple of %s", d, c.MultipleOf)
+	}
+	return nil
+}
+
+// ValidationError describes a constraint violation on a struct field
+type ValidationError struct {
+	Field string // Field is the path of the field, such as "Retry.Backoff[1]"
+	Err   error
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the fields of a struct, or pointer to struct, against the
+// constraints in their hdur tags:
+//
+//	type Config struct {
+//		Timeout hdur.Duration `hdur:"min=1s,max=5m,nocalendar"`
+//		Window  time.Duration `hdur:"positive,multipleof=15m"`
+//	}
+//
+// Tags apply to Duration and time.Duration fields, pointers to them (nil
+// pointers are skipped) and slices of them. Nested structs are validated
+// recursively. All violations are returned together, joined with
+// errors.Join, each as a *ValidationError.
+func Validate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate requires a struct, got %T", v)
+	}
+
+	var errs []error
+	validateStruct(rv, "", &errs)
+	return errors.Join(errs...)
+}
+
+var (
+	durationType    = reflect.TypeOf(Duration{})
+	stdDurationType = reflect.TypeOf(time.Duration(0))
+)
+
+// validateStruct validates every tagged field of v
+func validateStruct(v reflect.Value, prefix string, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + field.Name
+		tag, ok := field.Tag.Lookup("hdur")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				validateStruct(v.Field(i), path+".", errs)
+			}
+			continue
+		}
+
+		c, err := ParseConstraint(tag)
+		if err != nil {
+			*errs = append(*errs, &ValidationError{Field: path, Err: err})
+			continue
+		}
+		validateValue(v.Field(i), path, c, errs)
+	}
+}
+
+// validateValue checks a single tagged value against c
+func validateValue(v reflect.Value, path string, c Constraint, errs *[]error) {
+	var d Duration
+	switch v.Type() {
+	case durationType:
+		d = v.Interface().(Duration)
+	case stdDurationType:
+		d = FromStandard(time.Duration(v.Int()))
+		d.normalize()
+	default:
+		switch v.Kind() {
+		case reflect.Ptr:
+			if !v.IsNil() {
+				validateValue(v.Elem(), path, c, errs)
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), c, errs)
+			}
+		default:
+			*errs = append(*errs, &ValidationError{
+				Field: path,
+				Err:   fmt.Errorf("unsupported field type %s", v.Type()),
+			})
+		}
+		return
+	}
+
+	if err := c.Check(d); err != nil {
+		*errs = append(*errs, &ValidationError{Field: path, Err: err})
+	}
+}
@@ -0,0 +1,146 @@
+package hdur
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConstraint(t *testing.T) {
+	c, err := ParseConstraint("min=1s, max=5m,nocalendar,nonzero,positive,multipleof=15s")
+	if err != nil {
+		t.Fatalf("ParseConstraint() error = %v", err)
+	}
+	if c.Min == nil || *c.Min != Seconds(1) || c.Max == nil || *c.Max != Minutes(5) {
+		t.Errorf("bounds = %v, %v", c.Min, c.Max)
+	}
+	if c.MultipleOf != Seconds(15) || !c.NoCalendar || !c.NonZero || !c.Positive {
+		t.Errorf("ParseConstraint() = %+v", c)
+	}
+
+	for _, tag := range []string{"min", "max=soon", "multipleof=0s", "nonzero=1s", "between=1s"} {
+		if _, err := ParseConstraint(tag); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error", tag)
+		}
+	}
+}
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		d       Duration
+		wantErr string
+	}{
+		{"within bounds", "min=1s,max=5m", Minutes(2), ""},
+		{"at minimum", "min=1s,max=5m", Seconds(1), ""},
+		{"at maximum", "min=1s,max=5m", Minutes(5), ""},
+		{"below minimum", "min=1s", Milliseconds(500), "500ms must be at least 1s"},
+		{"above maximum", "max=5m", Minutes(6), "6m must be at most 5m"},
+		{"far above maximum", "max=5m", Duration{Days: 200000}, "200000d must be at most 5m"},
+		{"calendar units", "nocalendar", Months(1), "1mo must not use years or months"},
+		{"days are not calendar units", "nocalendar", Days(3), ""},
+		{"zero", "nonzero", Duration{}, "must not be zero"},
+		{"negative", "positive", Seconds(-1), "-1s must be positive"},
+		{"zero is not positive", "positive", Duration{}, "0s must be positive"},
+		{"multiple", "multipleof=15m", Hours(2), ""},
+		{"not a multiple", "multipleof=15m", Minutes(20), "20m must be a multiple of 15m"},
+		{"calendar multiple", "multipleof=3mo", Years(1), ""},
+		{"not a calendar multiple", "multipleof=3mo", Months(4), "4mo must be a multiple of 3mo"},
+		{"mixed against calendar multiple", "multipleof=1mo", Duration{Months: 1, Days: 1}, "must be a multiple"},
+		{"calendar against fixed multiple", "multipleof=1d", Months(1), "must be a multiple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConstraint(tt.tag)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+
+			err = c.Check(tt.d)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Check(%v) error = %v", tt.d, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Check(%v) error = %v, want %q", tt.d, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type retry struct {
+		Backoff []Duration `hdur:"positive,max=1m"`
+	}
+	type config struct {
+		Timeout   Duration       `hdur:"min=1s,max=5m,nocalendar"`
+		Window    time.Duration  `hdur:"positive,multipleof=15m"`
+		Retention *Duration      `hdur:"nonzero"`
+		Optional  *time.Duration `hdur:"nonzero"`
+		Retry     retry
+		Untagged  Duration
+	}
+
+	valid := config{
+		Timeout:   Seconds(30),
+		Window:    time.Hour,
+		Retention: &Duration{Years: 1},
+		Retry:     retry{Backoff: []Duration{Seconds(1), Seconds(5)}},
+	}
+	if err := Validate(valid); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := Validate(&valid); err != nil {
+		t.Errorf("Validate(pointer) error = %v", err)
+	}
+
+	zero := Duration{}
+	invalid := config{
+		Timeout:   Months(1),
+		Window:    20 * time.Minute,
+		Retention: &zero,
+		Retry:     retry{Backoff: []Duration{Seconds(1), Minutes(2)}},
+	}
+	err := Validate(&invalid)
+	if err == nil {
+		t.Fatal("Validate() expected error")
+	}
+
+	for _, want := range []string{
+		"Timeout: 1mo must not use years or months",
+		"Window: 20m must be a multiple of 15m",
+		"Retention: must not be zero",
+		"Retry.Backoff[1]: 2m must be at most 1m",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Timeout" {
+		t.Errorf("errors.As() = %+v", validationErr)
+	}
+}
+
+func TestValidate_InvalidInput(t *testing.T) {
+	if err := Validate(42); err == nil {
+		t.Error("Validate(int) expected error")
+	}
+
+	var badTag struct {
+		Timeout Duration `hdur:"min=never"`
+	}
+	if err := Validate(badTag); err == nil || !strings.Contains(err.Error(), "Timeout: invalid min constraint") {
+		t.Errorf("Validate() error = %v, want invalid constraint", err)
+	}
+
+	var badType struct {
+		Count int `hdur:"positive"`
+	}
+	if err := Validate(badType); err == nil || !strings.Contains(err.Error(), "unsupported field type int") {
+		t.Errorf("Validate() error = %v, want unsupported field type", err)
+	}
+}
@@ -0,0 +1,47 @@
+package hdur
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// MarshalXML implements xml.Marshaler, writing the canonical text encoding.
+// Use ISODuration for the xs:duration lexical form.
+func (d Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(d.Canonical(), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. It accepts both the hdur string
+// form and xs:duration values such as "P1DT12H".
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(strings.TrimSpace(s)))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: d.Canonical()}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, accepting the same forms
+// as UnmarshalXML
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalXML implements xml.Marshaler, writing the xs:duration lexical form.
+// A duration whose components differ in sign has no xs:duration
+// representation and is written with component signs, which schema
+// validators reject.
+func (d ISODuration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(d.ISO8601(), start)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, writing the xs:duration
+// lexical form
+func (d ISODuration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: d.ISO8601()}, nil
+}
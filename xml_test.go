@@ -0,0 +1,91 @@
+package hdur
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestDurationXML(t *testing.T) {
+	type config struct {
+		XMLName  xml.Name    `xml:"config"`
+		Interval Duration    `xml:"interval,attr"`
+		Timeout  ISODuration `xml:"timeout,attr"`
+		TTL      Duration    `xml:"ttl"`
+		Expiry   ISODuration `xml:"expiry"`
+		Optional *Duration   `xml:"optional,omitempty"`
+	}
+
+	in := config{
+		Interval: Minutes(90),
+		Timeout:  ISODuration{Seconds(30)},
+		TTL:      Duration{Days: 1, Nanos: 1500},
+		Expiry:   ISODuration{Duration{Years: -1, Months: -6}},
+	}
+	want := `<config interval="1h 30m" timeout="PT30S"><ttl>1d 1500ns</ttl><expiry>-P1Y6M</expiry></config>`
+
+	got, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	var out config
+	if err := xml.Unmarshal(got, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	out.XMLName = xml.Name{}
+	in.XMLName = xml.Name{}
+	if out != in {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestDurationXML_Unmarshal(t *testing.T) {
+	type feed struct {
+		Every  Duration `xml:"every,attr"`
+		Period Duration `xml:"period"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    feed
+		wantErr bool
+	}{
+		{
+			name:  "native forms",
+			input: `<feed every="15m"><period>1 year 2 months</period></feed>`,
+			want:  feed{Every: Minutes(15), Period: Duration{Years: 1, Months: 2}},
+		},
+		{
+			name:  "xs:duration forms",
+			input: `<feed every="PT15M"><period>` + "\n\t" + `P1Y2M` + "\n" + `</period></feed>`,
+			want:  feed{Every: Minutes(15), Period: Duration{Years: 1, Months: 2}},
+		},
+		{
+			name:    "invalid attribute",
+			input:   `<feed every="soon"><period>P1D</period></feed>`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid element",
+			input:   `<feed every="PT1M"><period>P1X</period></feed>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got feed
+			err := xml.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}